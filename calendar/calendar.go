@@ -0,0 +1,238 @@
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/icza/gox/timex"
+)
+
+// Rule decides which month a week belongs to, and so its week block within that month
+type Rule string
+
+const (
+	// RuleFirstMonday assigns a week to the month of its Monday, counting blocks from the first Monday of the month
+	RuleFirstMonday Rule = "first-monday"
+	// RuleISO assigns a week to the month of its Thursday, as ISO 8601 does for years
+	RuleISO Rule = "iso"
+	// RuleMajority assigns a week to the month containing most of its logged days, the earlier month on a tie
+	RuleMajority Rule = "majority"
+
+	DefaultRule = RuleFirstMonday
+)
+
+// Rules returns the supported rules
+func Rules() []Rule {
+	return []Rule{RuleFirstMonday, RuleISO, RuleMajority}
+}
+
+// ParseRule returns the rule named s, or DefaultRule if s is empty
+func ParseRule(s string) (Rule, error) {
+
+	if s == "" {
+		return DefaultRule, nil
+	}
+
+	for _, rule := range Rules() {
+		if string(rule) == s {
+			return rule, nil
+		}
+	}
+
+	names := make([]string, len(Rules()))
+
+	for i, rule := range Rules() {
+		names[i] = string(rule)
+	}
+
+	return "", fmt.Errorf("unknown week rule %q: expected one of %s", s, strings.Join(names, ", "))
+
+}
+
+// Placement is where a week is logged: the month tab and the zero based week block within it
+type Placement struct {
+	Year  int
+	Month time.Month
+	Block int
+	// CrossMonth reports whether the logged days span two months
+	CrossMonth bool
+}
+
+// Date returns the first day of the placement's month
+func (p Placement) Date() time.Time {
+	return time.Date(p.Year, p.Month, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func (p Placement) String() string {
+	return fmt.Sprintf("%s %d, week %d", p.Month, p.Year, p.Block+1)
+}
+
+// WeekStart returns the Monday on or before date
+func WeekStart(date time.Time) time.Time {
+
+	y, m, d := date.Date()
+
+	offset := (int(date.Weekday()) + 6) % 7 // days since Monday
+
+	return time.Date(y, m, d-offset, 0, 0, 0, 0, date.Location())
+
+}
+
+var isoWeekPattern = regexp.MustCompile(`^(\d{4})-W(\d{2})$`)
+
+// ParseISOWeek returns the Monday of the ISO week s e.g. 2026-W38
+func ParseISOWeek(s string) (time.Time, error) {
+
+	match := isoWeekPattern.FindStringSubmatch(s)
+
+	if match == nil {
+		return time.Time{}, fmt.Errorf("%q is not an ISO week e.g. 2026-W38", s)
+	}
+
+	year, _ := strconv.Atoi(match[1])
+	week, _ := strconv.Atoi(match[2])
+
+	if week < 1 || week > weeksInYear(year) {
+		return time.Time{}, fmt.Errorf("%d has no week %d", year, week)
+	}
+
+	return timex.WeekStart(year, week), nil
+
+}
+
+// weeksInYear returns the number of ISO weeks in year, 52 or 53
+func weeksInYear(year int) int {
+
+	/// December 28th always falls in the last week of its year
+	_, week := time.Date(year, time.December, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+
+	return week
+
+}
+
+// WeeksAgo returns the Monday of the week n weeks before the week of now, as a UTC date
+func WeeksAgo(now time.Time, n int) (time.Time, error) {
+
+	if n < 0 {
+		return time.Time{}, errors.New("must not be negative")
+	}
+
+	y, m, d := WeekStart(now).Date()
+
+	return time.Date(y, m, d-7*n, 0, 0, 0, 0, time.UTC), nil
+
+}
+
+// LastWorkingWeek returns the Monday of the last working week relative to now: the current week on weekends, otherwise the previous week
+func LastWorkingWeek(now time.Time) time.Time {
+
+	currentYear, currentWeek := now.ISOWeek()
+
+	dayOfWeek := int(now.Weekday())
+
+	var relevantWeekOffset int
+
+	/// Relevant days to work with is working days of the week (Mon - Fri)
+	/// If it's weekend (Saturday, or Sunday), work with current week (Mon - Fri stats is available)
+	/// Else, 1 week needs to be offset from current week in order to work with last week's data as the current working week is not yet over
+
+	if dayOfWeek == 6 || dayOfWeek == 0 {
+		relevantWeekOffset = 0
+
+	} else {
+		relevantWeekOffset = 1
+	}
+
+	return timex.WeekStart(currentYear, currentWeek-relevantWeekOffset)
+
+}
+
+// Place returns where the days from start to end (inclusive) are logged according to rule.
+// Days are expected to fall within a single week.
+func Place(start, end time.Time, rule Rule) Placement {
+
+	monday := WeekStart(start)
+
+	// offset and length of the logged days within their week, applied to earlier weeks when counting blocks
+	offset := daysBetween(monday, start)
+	length := daysBetween(start, end) + 1
+
+	owner := func(monday time.Time) (int, time.Month) {
+		return ownerMonth(monday, offset, length, rule)
+	}
+
+	year, month := owner(monday)
+
+	/// Count earlier weeks belonging to the same month. A month spans at most 6 weeks.
+	block := 0
+
+	for i := 1; i <= 6; i++ {
+
+		y, m := owner(monday.AddDate(0, 0, -7*i))
+
+		if y != year || m != month {
+			break
+		}
+
+		block++
+
+	}
+
+	return Placement{
+		Year:       year,
+		Month:      month,
+		Block:      block,
+		CrossMonth: start.Month() != end.Month() || start.Year() != end.Year(),
+	}
+
+}
+
+func ownerMonth(monday time.Time, offset, length int, rule Rule) (int, time.Month) {
+
+	var date time.Time
+
+	switch rule {
+	case RuleISO:
+
+		date = monday.AddDate(0, 0, 3) // Thursday
+
+	case RuleMajority:
+
+		start := monday.AddDate(0, 0, offset)
+		end := start.AddDate(0, 0, length-1)
+
+		date = start
+
+		if start.Month() != end.Month() {
+
+			firstOfNextMonth := time.Date(end.Year(), end.Month(), 1, 0, 0, 0, 0, end.Location())
+
+			if daysInEndMonth := daysBetween(firstOfNextMonth, end) + 1; daysInEndMonth > length-daysInEndMonth {
+				date = end
+			}
+
+		}
+
+	default:
+
+		date = monday
+
+	}
+
+	return date.Year(), date.Month()
+
+}
+
+// daysBetween returns the number of calendar days from a to b, ignoring time of day and DST shifts
+func daysBetween(a, b time.Time) int {
+
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+
+	return int(time.Date(by, bm, bd, 0, 0, 0, 0, time.UTC).Sub(time.Date(ay, am, ad, 0, 0, 0, 0, time.UTC)).Hours() / 24)
+
+}
@@ -0,0 +1,167 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestPlace(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		monday      time.Time
+		workingDays int
+		rule        Rule
+		want        Placement
+	}{
+		{name: "mid month first-monday", monday: date(2026, time.October, 12), workingDays: 5, rule: RuleFirstMonday, want: Placement{2026, time.October, 1, false}},
+		{name: "mid month iso", monday: date(2026, time.October, 12), workingDays: 5, rule: RuleISO, want: Placement{2026, time.October, 2, false}},
+		{name: "mid month majority", monday: date(2026, time.October, 12), workingDays: 5, rule: RuleMajority, want: Placement{2026, time.October, 1, false}},
+
+		{name: "starts on the 29th first-monday", monday: date(2026, time.June, 29), workingDays: 5, rule: RuleFirstMonday, want: Placement{2026, time.June, 4, true}},
+		{name: "starts on the 29th iso", monday: date(2026, time.June, 29), workingDays: 5, rule: RuleISO, want: Placement{2026, time.July, 0, true}},
+		{name: "starts on the 29th majority", monday: date(2026, time.June, 29), workingDays: 5, rule: RuleMajority, want: Placement{2026, time.July, 0, true}},
+		{name: "majority tie goes to the earlier month", monday: date(2026, time.June, 29), workingDays: 4, rule: RuleMajority, want: Placement{2026, time.June, 4, true}},
+
+		{name: "starts on the 30th first-monday", monday: date(2026, time.March, 30), workingDays: 5, rule: RuleFirstMonday, want: Placement{2026, time.March, 4, true}},
+		{name: "starts on the 30th iso", monday: date(2026, time.March, 30), workingDays: 5, rule: RuleISO, want: Placement{2026, time.April, 0, true}},
+		{name: "starts on the 30th majority 7 days", monday: date(2026, time.March, 30), workingDays: 7, rule: RuleMajority, want: Placement{2026, time.April, 0, true}},
+
+		{name: "starts on the 31st first-monday", monday: date(2025, time.March, 31), workingDays: 5, rule: RuleFirstMonday, want: Placement{2025, time.March, 4, true}},
+		{name: "starts on the 31st iso", monday: date(2025, time.March, 31), workingDays: 5, rule: RuleISO, want: Placement{2025, time.April, 0, true}},
+		{name: "starts on the 31st majority", monday: date(2025, time.March, 31), workingDays: 5, rule: RuleMajority, want: Placement{2025, time.April, 0, true}},
+
+		{name: "december to january first-monday", monday: date(2025, time.December, 29), workingDays: 5, rule: RuleFirstMonday, want: Placement{2025, time.December, 4, true}},
+		{name: "december to january iso", monday: date(2025, time.December, 29), workingDays: 5, rule: RuleISO, want: Placement{2026, time.January, 0, true}},
+		{name: "december to january majority 5 days", monday: date(2025, time.December, 29), workingDays: 5, rule: RuleMajority, want: Placement{2025, time.December, 4, true}},
+		{name: "december to january majority 7 days", monday: date(2025, time.December, 29), workingDays: 7, rule: RuleMajority, want: Placement{2026, time.January, 0, true}},
+
+		{name: "53rd week first-monday", monday: date(2026, time.December, 28), workingDays: 5, rule: RuleFirstMonday, want: Placement{2026, time.December, 3, true}},
+		{name: "53rd week iso", monday: date(2026, time.December, 28), workingDays: 5, rule: RuleISO, want: Placement{2026, time.December, 4, true}},
+		{name: "53rd week majority 7 days", monday: date(2026, time.December, 28), workingDays: 7, rule: RuleMajority, want: Placement{2026, time.December, 4, true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			end := tt.monday.AddDate(0, 0, tt.workingDays-1)
+
+			if got := Place(tt.monday, end, tt.rule); got != tt.want {
+				t.Errorf("Place(%s, %s, %s) = %+v, want %+v", tt.monday.Format(time.DateOnly), end.Format(time.DateOnly), tt.rule, got, tt.want)
+			}
+
+		})
+	}
+
+}
+
+func TestParseISOWeek(t *testing.T) {
+
+	tests := []struct {
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{input: "2026-W38", want: date(2026, time.September, 14)},
+		{input: "2026-W01", want: date(2025, time.December, 29)},
+		{input: "2026-W53", want: date(2026, time.December, 28)},
+		{input: "2025-W53", wantErr: true},
+		{input: "2026-W54", wantErr: true},
+		{input: "2026-W00", wantErr: true},
+		{input: "2026-W38x", wantErr: true},
+		{input: "2026-W3", wantErr: true},
+		{input: "2026W38", wantErr: true},
+		{input: " 2026-W38", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+
+			got, err := ParseISOWeek(tt.input)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseISOWeek(%q) = %s, want an error", tt.input, got)
+				}
+				return
+			}
+
+			if err != nil || !got.Equal(tt.want) {
+				t.Errorf("ParseISOWeek(%q) = %s, %v, want %s", tt.input, got, err, tt.want)
+			}
+
+		})
+	}
+
+}
+
+func TestLastWorkingWeek(t *testing.T) {
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{name: "weekday is the previous week", now: date(2026, time.October, 14), want: date(2026, time.October, 5)},
+		{name: "saturday is the current week", now: date(2026, time.October, 17), want: date(2026, time.October, 12)},
+		{name: "sunday is the current week", now: date(2026, time.October, 18), want: date(2026, time.October, 12)},
+		{name: "monday in the first week of the year", now: date(2026, time.January, 5), want: date(2025, time.December, 29)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			if got := LastWorkingWeek(tt.now); !got.Equal(tt.want) {
+				t.Errorf("LastWorkingWeek(%s) = %s, want %s", tt.now.Format(time.DateOnly), got.Format(time.DateOnly), tt.want.Format(time.DateOnly))
+			}
+
+		})
+	}
+
+}
+
+func TestWeeksAgo(t *testing.T) {
+
+	lagos := time.FixedZone("WAT", 60*60)
+
+	tests := []struct {
+		name    string
+		now     time.Time
+		n       int
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "current week", now: date(2026, time.October, 14), n: 0, want: date(2026, time.October, 12)},
+		{name: "one week ago", now: date(2026, time.October, 14), n: 1, want: date(2026, time.October, 5)},
+		{name: "from sunday", now: date(2026, time.October, 18), n: 2, want: date(2026, time.September, 28)},
+		{name: "across the year", now: date(2026, time.January, 7), n: 2, want: date(2025, time.December, 22)},
+		{name: "from a 53 week year", now: date(2027, time.January, 6), n: 1, want: date(2026, time.December, 28)},
+		{name: "local date is used", now: time.Date(2026, time.October, 19, 0, 30, 0, 0, lagos), n: 1, want: date(2026, time.October, 12)},
+		{name: "negative", now: date(2026, time.October, 14), n: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			got, err := WeeksAgo(tt.now, tt.n)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("WeeksAgo(%s, %d) = %s, want an error", tt.now, tt.n, got)
+				}
+				return
+			}
+
+			if err != nil || !got.Equal(tt.want) {
+				t.Errorf("WeeksAgo(%s, %d) = %s, %v, want %s", tt.now, tt.n, got, err, tt.want)
+			}
+
+		})
+	}
+
+}
@@ -3,6 +3,7 @@ package auth
 import (
 	"fmt"
 
+	"github.com/Youngtard/wakalog/pkg/cmdutil"
 	"github.com/Youngtard/wakalog/wakalog"
 	"github.com/Youngtard/wakalog/wakatime"
 	"github.com/spf13/cobra"
@@ -11,23 +12,31 @@ import (
 func NewAuthCmd(app *wakalog.Application) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "auth",
-		Short: "Authorize WakaTime.",
-		Long:  "Authorize WakaTime with API Key.",
+		Short: "Authorize WakaTime and Google Sheets.",
+		Long:  "Authorize WakaTime with API Key. Use subcommands to authorize Google Sheets, check status or log out.",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 
-			_, err := wakatime.Authorize(cmd.Context())
+			if !cmdutil.IsInteractive() {
+				return &wakalog.AuthError{Err: fmt.Errorf("cannot prompt for WakaTime API Key when not running interactively: set the %s environment variable instead", wakatime.APIKeyEnv)}
+			}
+
+			_, user, err := wakatime.Authorize(cmd.Context(), app.WakaTimeURL)
 
 			if err != nil {
 				return &wakalog.AuthError{Err: fmt.Errorf("error authenticating with WakaTime: %w", err)}
 			}
 
-			fmt.Println("WakaTime auth successful!")
+			fmt.Printf("WakaTime auth successful! Logged in as %s.\n", user)
 
 			return nil
 		},
 	}
 
+	cmd.AddCommand(newStatusCmd(app))
+	cmd.AddCommand(newLogoutCmd(app))
+	cmd.AddCommand(newGoogleCmd(app))
+
 	return cmd
 
 }
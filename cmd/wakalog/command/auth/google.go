@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"fmt"
+
+	"github.com/Youngtard/wakalog/pkg/cmdutil"
+	wakasheets "github.com/Youngtard/wakalog/sheets"
+	"github.com/Youngtard/wakalog/wakalog"
+	"github.com/spf13/cobra"
+)
+
+func newGoogleCmd(app *wakalog.Application) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "google",
+		Short: "Authorize Google Sheets.",
+		Long:  "Authorize Google Sheets in the browser, replacing any stored token.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			if !cmdutil.IsInteractive() {
+				return &wakalog.AuthError{Err: fmt.Errorf("cannot authorize Google Sheets when not running interactively")}
+			}
+
+			err := wakasheets.Authorize(cmd.Context(), app.Config.AuthorizationTimeout())
+
+			if err != nil {
+				return &wakalog.AuthError{Err: fmt.Errorf("error authenticating with Google Sheets: %w", err)}
+			}
+
+			fmt.Println("Google Sheets auth successful!")
+
+			return nil
+		},
+	}
+
+	return cmd
+
+}
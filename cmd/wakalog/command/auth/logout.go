@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"fmt"
+
+	wakasheets "github.com/Youngtard/wakalog/sheets"
+	"github.com/Youngtard/wakalog/wakalog"
+	"github.com/Youngtard/wakalog/wakatime"
+	"github.com/spf13/cobra"
+)
+
+func newLogoutCmd(app *wakalog.Application) *cobra.Command {
+
+	var logoutWakaTime bool
+	var logoutGoogle bool
+
+	cmd := &cobra.Command{
+		Use:   "logout",
+		Short: "Remove stored credentials.",
+		Long:  "Remove the stored WakaTime API Key and Google Sheets token. Both are removed unless --wakatime or --google is set.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			if !logoutWakaTime && !logoutGoogle {
+				logoutWakaTime = true
+				logoutGoogle = true
+			}
+
+			if logoutWakaTime {
+
+				err := wakatime.DeleteAPIKey(app.WakaTimeURL)
+
+				if err != nil {
+					return err
+				}
+
+				fmt.Printf("Removed WakaTime API Key for %s.\n", app.WakaTimeURL)
+
+			}
+
+			if logoutGoogle {
+
+				store, err := wakasheets.NewTokenStore()
+
+				if err != nil {
+					return err
+				}
+
+				err = store.Delete()
+
+				if err != nil {
+					return err
+				}
+
+				fmt.Println("Removed Google Sheets token.")
+
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&logoutWakaTime, "wakatime", false, "Only remove the WakaTime API Key")
+	cmd.Flags().BoolVar(&logoutGoogle, "google", false, "Only remove the Google Sheets token")
+
+	cmd.MarkFlagsMutuallyExclusive("wakatime", "google")
+
+	return cmd
+
+}
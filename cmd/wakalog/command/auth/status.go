@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Youngtard/wakalog/pkg/cmdutil"
+	wakasheets "github.com/Youngtard/wakalog/sheets"
+	"github.com/Youngtard/wakalog/wakalog"
+	"github.com/Youngtard/wakalog/wakatime"
+	"github.com/spf13/cobra"
+)
+
+func newStatusCmd(app *wakalog.Application) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show authorization status.",
+		Long:  "Show configured accounts, validating the WakaTime API Key and the Google Sheets token.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			var failed bool
+
+			fmt.Printf("WakaTime (%s)\n", app.WakaTimeURL)
+
+			apiKey, err := cmdutil.GetWakaTimeAPIKey(app.WakaTimeURL)
+
+			if err != nil {
+
+				failed = true
+
+				if errors.Is(err, wakalog.ErrWakaTimeAPIKeyNotFound) {
+					fmt.Println("  ✗ Not authorized. Use <wakalog auth> to authorize WakaTime.")
+				} else {
+					fmt.Printf("  ✗ %s\n", err)
+				}
+
+			} else {
+
+				source := "keyring"
+
+				if strings.TrimSpace(os.Getenv(wakatime.APIKeyEnv)) != "" {
+					source = wakatime.APIKeyEnv
+				}
+
+				app.InitializeWakaTime(apiKey)
+
+				user, err := app.WakaTime.GetCurrentUser(ctx)
+
+				if err != nil {
+					failed = true
+					fmt.Printf("  ✗ API Key from %s is invalid: %s\n", source, err)
+				} else {
+					fmt.Printf("  ✓ Logged in as %s (API Key from %s)\n", user, source)
+				}
+
+			}
+
+			fmt.Println("Google Sheets")
+
+			info, err := wakasheets.GetTokenInfo(ctx)
+
+			if err != nil {
+
+				failed = true
+
+				if errors.Is(err, wakasheets.ErrTokenNotFound) {
+					fmt.Println("  ✗ Not authorized. Use <wakalog auth google> to authorize Google Sheets.")
+				} else {
+					fmt.Printf("  ✗ %s. Use <wakalog auth google> to reauthorize Google Sheets.\n", err)
+				}
+
+			} else {
+
+				if info.Email != "" {
+					fmt.Printf("  ✓ Authorized as %s, access token expires %s\n", info.Email, info.Expiry.Local().Format(time.DateTime))
+				} else {
+					fmt.Printf("  ✓ Authorized, access token expires %s\n", info.Expiry.Local().Format(time.DateTime))
+					fmt.Println("  ! Account email unknown, use <wakalog auth google> to reauthorize and show it")
+				}
+
+				if !info.HasRefreshToken {
+					fmt.Println("  ! No refresh token stored, authorization will be required once the access token expires")
+				}
+
+				if len(info.MissingScopes) > 0 {
+					failed = true
+					fmt.Printf("  ✗ Missing scopes: %s. Use <wakalog auth google> to reauthorize Google Sheets.\n", strings.Join(info.MissingScopes, ", "))
+				} else {
+					fmt.Printf("  ✓ Scopes: %s\n", strings.Join(info.Scopes, ", "))
+				}
+
+			}
+
+			if failed {
+				return &wakalog.AuthError{Err: errors.New("one or more accounts are not authorized")}
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+
+}
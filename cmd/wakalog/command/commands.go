@@ -2,7 +2,9 @@ package command
 
 import (
 	"github.com/Youngtard/wakalog/cmd/wakalog/command/auth"
+	"github.com/Youngtard/wakalog/cmd/wakalog/command/config"
 	"github.com/Youngtard/wakalog/cmd/wakalog/command/log"
+	"github.com/Youngtard/wakalog/cmd/wakalog/command/summary"
 	"github.com/Youngtard/wakalog/wakalog"
 	"github.com/spf13/cobra"
 )
@@ -10,6 +12,8 @@ import (
 func addCommands(cmd *cobra.Command, app *wakalog.Application) {
 
 	cmd.AddCommand(log.NewLogCommand(app))
+	cmd.AddCommand(summary.NewSummaryCommand(app))
 	cmd.AddCommand(auth.NewAuthCmd(app))
+	cmd.AddCommand(config.NewConfigCmd(app))
 
 }
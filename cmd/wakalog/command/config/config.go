@@ -0,0 +1,152 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Youngtard/wakalog/wakalog"
+	"github.com/spf13/cobra"
+)
+
+func NewConfigCmd(app *wakalog.Application) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config <command>",
+		Short: "Manage wakalog configuration.",
+		Long:  "Display or change configuration settings persisted between runs.",
+		Args:  cobra.NoArgs,
+	}
+
+	cmd.AddCommand(newGetCmd(app))
+	cmd.AddCommand(newSetCmd(app))
+	cmd.AddCommand(newUnsetCmd(app))
+	cmd.AddCommand(newListCmd(app))
+
+	return cmd
+
+}
+
+func newGetCmd(app *wakalog.Application) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get <key>",
+		Short: "Print the value of a configuration key.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			value, err := app.Config.Get(args[0])
+
+			if err != nil {
+				return configKeyError(err)
+			}
+
+			if value != "" {
+				fmt.Println(value)
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+
+}
+
+func newSetCmd(app *wakalog.Application) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Update a configuration key.",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			err := app.Config.Set(args[0], args[1])
+
+			if err != nil {
+				return configKeyError(err)
+			}
+
+			err = app.Config.Save()
+
+			if err != nil {
+				return fmt.Errorf("error saving config: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+
+}
+
+func newUnsetCmd(app *wakalog.Application) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unset <key>",
+		Short: "Remove a configuration key.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			err := app.Config.Unset(args[0])
+
+			if err != nil {
+				return configKeyError(err)
+			}
+
+			err = app.Config.Save()
+
+			if err != nil {
+				return fmt.Errorf("error saving config: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+
+}
+
+func newListCmd(app *wakalog.Application) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "Print all configuration keys and their values.",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			for _, option := range wakalog.ConfigOptions() {
+
+				value, err := app.Config.Get(option.Name)
+
+				if err != nil {
+					return err
+				}
+
+				fmt.Printf("%s=%s\n", option.Name, value)
+
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+
+}
+
+// configKeyError reports unknown keys and invalid values as usage errors along with the supported keys
+func configKeyError(err error) error {
+
+	if errors.Is(err, wakalog.ErrUnknownConfigKey) {
+
+		msg := fmt.Sprintf("%s\n\nSupported keys:", err)
+
+		for _, option := range wakalog.ConfigOptions() {
+			msg += fmt.Sprintf("\n  %-16s %s", option.Name, option.Description)
+		}
+
+		return &wakalog.FlagError{Err: errors.New(msg)}
+
+	}
+
+	return &wakalog.FlagError{Err: err}
+
+}
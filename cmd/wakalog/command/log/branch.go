@@ -0,0 +1,78 @@
+package log
+
+import (
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Youngtard/wakalog/wakatime"
+)
+
+// validateBranchPatterns returns the first malformed --branch pattern
+func validateBranchPatterns(patterns []string) (string, error) {
+
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return pattern, err
+		}
+	}
+
+	return "", nil
+
+}
+
+// literalBranches returns patterns when none of them is a glob, so branches can be filtered by the WakaTime API.
+// Globs can only be matched against the branches returned for the whole project.
+func literalBranches(patterns []string) []string {
+
+	for _, pattern := range patterns {
+		if strings.ContainsAny(pattern, `*?[\`) {
+			return nil
+		}
+	}
+
+	return patterns
+
+}
+
+// matchesBranch reports whether branch matches any of patterns e.g. main or release/*
+func matchesBranch(patterns []string, branch string) bool {
+
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, branch); matched {
+			return true
+		}
+	}
+
+	return false
+
+}
+
+// branchTimeForDay returns the time spent on branches matching patterns, or the whole day when there are no patterns.
+// Matched branch names are added to matched.
+func branchTimeForDay(data wakatime.SummariesData, patterns []string, matched *[]string) time.Duration {
+
+	if len(patterns) == 0 {
+		return time.Duration(data.GrandTotal.TotalSeconds * float64(time.Second))
+	}
+
+	var seconds float64
+
+	for _, branch := range data.Branches {
+
+		if !matchesBranch(patterns, branch.Name) {
+			continue
+		}
+
+		seconds += branch.TotalSeconds
+
+		if !slices.Contains(*matched, branch.Name) {
+			*matched = append(*matched, branch.Name)
+		}
+
+	}
+
+	return time.Duration(seconds * float64(time.Second))
+
+}
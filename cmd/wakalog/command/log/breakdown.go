@@ -0,0 +1,88 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	wakasheets "github.com/Youngtard/wakalog/sheets"
+	"github.com/Youngtard/wakalog/wakalog"
+	"github.com/Youngtard/wakalog/wakatime"
+)
+
+// getBreakdownMetrics returns the top languages, editors and categories of the selected projects.
+// Summaries are fetched per project so breakdowns exclude activity on other projects, and on other branches when branches are given.
+func getBreakdownMetrics(ctx context.Context, app *wakalog.Application, startDate, endDate time.Time, selectedProjects, branches []string, topN int) (map[string]interface{}, error) {
+
+	languages := map[string]float64{}
+	editors := map[string]float64{}
+	categories := map[string]float64{}
+
+	for _, project := range selectedProjects {
+
+		summaries, err := app.WakaTime.GetSummaries(ctx, startDate, endDate, &wakatime.SummariesOptions{Project: project, Branches: branches, Timezone: app.Timezone})
+
+		if err != nil {
+			return nil, fmt.Errorf("error getting summaries of project %s: %w", project, err)
+		}
+
+		for _, data := range summaries.Data {
+			addItems(languages, data.Languages)
+			addItems(editors, data.Editors)
+			addItems(categories, data.Categories)
+		}
+
+	}
+
+	return map[string]interface{}{
+		wakasheets.MetricTopLanguages:  formatTopItems(languages, topN),
+		wakasheets.MetricTopEditors:    formatTopItems(editors, topN),
+		wakasheets.MetricTopCategories: formatTopItems(categories, topN),
+	}, nil
+
+}
+
+func addItems(totals map[string]float64, items []wakatime.SummaryItem) {
+
+	for _, item := range items {
+		totals[item.Name] += item.TotalSeconds
+	}
+
+}
+
+// formatTopItems formats the n items with the most time, with hours e.g. "Go (12.5h), TypeScript (3.0h)"
+func formatTopItems(totals map[string]float64, n int) string {
+
+	names := make([]string, 0, len(totals))
+
+	for name, seconds := range totals {
+		if seconds > 0 {
+			names = append(names, name)
+		}
+	}
+
+	slices.SortFunc(names, func(a, b string) int {
+		if totals[a] != totals[b] {
+			if totals[a] > totals[b] {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a, b)
+	})
+
+	if len(names) > n {
+		names = names[:n]
+	}
+
+	items := make([]string, len(names))
+
+	for i, name := range names {
+		items[i] = fmt.Sprintf("%s (%.1fh)", name, totals[name]/3600)
+	}
+
+	return strings.Join(items, ", ")
+
+}
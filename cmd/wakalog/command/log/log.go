@@ -4,57 +4,113 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"maps"
 	"net/http"
+	"os"
 
 	"slices"
 	"strings"
 	"time"
 
+	"github.com/Youngtard/wakalog/pkg/cmdutil"
 	wakasheets "github.com/Youngtard/wakalog/sheets"
+	"github.com/Youngtard/wakalog/stats"
 	"github.com/Youngtard/wakalog/wakalog"
 	"github.com/Youngtard/wakalog/wakatime"
 	"github.com/charmbracelet/huh"
-	"github.com/icza/gox/timex"
 	"github.com/savioxavier/termlink"
 	"github.com/spf13/cobra"
-	"github.com/zalando/go-keyring"
 	"google.golang.org/api/sheets/v4"
 )
 
 var errNoProjects = errors.New("no projects")
 
+// errNoActivity is returned by updateSheet when no time was spent on the selected projects during the period
+var errNoActivity = errors.New("no activity")
+
+// errNotWritten is returned by updateSheet when values are not written on the sheet on a dry run
+var errNotWritten = errors.New("values not written")
+
+type LogOptions struct {
+	Name        string
+	Projects    []string
+	AllProjects bool
+	Project     string
+	Branches    []string
+	Yes         bool
+	NoInput     bool
+	DryRun      bool
+	LayoutFile  string
+	CreateTab   bool
+
+	Week     string
+	From     string
+	To       string
+	WeeksAgo int
+	Backfill bool
+
+	// Interactive reports whether prompts can be shown, determined from NoInput and TTY detection
+	Interactive bool
+	// Messages receives progress messages, stderr when results are printed as JSON or YAML
+	Messages io.Writer
+}
+
 func NewLogCommand(app *wakalog.Application) *cobra.Command {
 
+	opts := &LogOptions{}
+
 	cmd := &cobra.Command{
 		Use:   "log",
 		Short: "Log your summary activity",
-		Long:  "Log your weekly summary activity to a Spreadsheet",
-		Args:  cobra.NoArgs,
+		Long: `Log your weekly summary activity to a Spreadsheet.
+
+Prompts are skipped when the values are provided through flags or configuration.
+When not attached to a terminal (or with --no-input), missing values result in an error instead of a prompt.
+The WakaTime API key can be provided through the WAKATIME_API_KEY environment variable.`,
+		Example: `  wakalog log
+  wakalog log --name "Jane Doe" --projects wakalog,api
+  wakalog log --project wakalog --branch main,release/*
+  wakalog log --all-projects --no-input`,
+		Args: cobra.NoArgs,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 
-			var wakatimeAPIKey string
-			var sheetsClient *http.Client
+			/// Flags are validated before prompting or authorizing, flag groups already were by the root command
+			if len(opts.Branches) > 0 && opts.Project == "" {
+				return &wakalog.FlagError{Err: errors.New("--branch requires --project")}
+			}
 
-			wakatimeAPIKey, err := checkForWakaTimeAPIKey()
+			if pattern, err := validateBranchPatterns(opts.Branches); err != nil {
+				return &wakalog.FlagError{Err: fmt.Errorf("invalid --branch %q: %w", pattern, err)}
+			}
 
-			if err != nil {
-				if errors.Is(err, wakalog.ErrWakaTimeAPIKeyNotFound) {
+			opts.Interactive = !opts.NoInput && cmdutil.IsInteractive()
 
-					wakatimeAPIKey, err = wakatime.Authorize(ctx)
+			opts.Messages = os.Stdout
 
-					if err != nil {
-						return &wakalog.AuthError{Err: fmt.Errorf("error authenticating with WakaTime: %w", err)}
-					}
+			if cmdutil.IsStructuredOutput(app.Output) {
+				opts.Messages = os.Stderr
+			}
 
-				} else {
-					return fmt.Errorf("error checking for wakatime api key: %w", err)
-				}
+			var sheetsClient *http.Client
+
+			wakatimeAPIKey, err := cmdutil.EnsureWakaTimeAPIKey(ctx, app.WakaTimeURL, opts.Interactive, opts.Messages)
+
+			if err != nil {
+				return err
 			}
 
-			sheetsClient, err = wakasheets.GetClient(ctx)
+			sheetsClient, err = wakasheets.GetClient(ctx, opts.Interactive, app.Config.AuthorizationTimeout())
 
 			if err != nil {
+				if errors.Is(err, wakasheets.ErrAuthorizationRequired) {
+					return &wakalog.AuthError{Err: fmt.Errorf("%w: run <wakalog log> in a terminal to authorize Google Sheets", err)}
+				}
+				/// Authorization in the browser timed out
+				if errors.Is(err, context.DeadlineExceeded) {
+					return &wakalog.AuthError{Err: fmt.Errorf("error authorizing google sheets: %w", err)}
+				}
 				return fmt.Errorf("error getting google client: %w", err)
 			}
 
@@ -72,312 +128,607 @@ func NewLogCommand(app *wakalog.Application) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 
-			var username string
-			var relevantSheet string
-			var relevantSheetId int64
-
 			sheetsService := app.Sheets
+			config := app.Config
 
-			ssheet, err := sheetsService.Spreadsheets.Get(wakasheets.SpreadsheetId).Do()
+			rule, err := config.Rule()
 
 			if err != nil {
-				// TODO test errors and other errors
 				return err
 			}
 
-			startDate, _ := getRelevantStartAndEndDate()
-			relevantMonth := startDate.Month()
+			periods, err := getPeriods(opts, app.Now(), config.WeekLength(), rule)
 
-			for i, s := range ssheet.Sheets {
+			if err != nil {
+				return err
+			}
 
-				if i == int(relevantMonth)-1 {
+			layout, err := getLayout(opts, config)
 
-					relevantSheetId = s.Properties.SheetId
-					relevantSheet = s.Properties.Title
-					break
-				}
+			if err != nil {
+				return err
+			}
 
+			spreadsheetId := config.SpreadsheetID
+
+			if spreadsheetId == "" {
+				spreadsheetId = wakasheets.SpreadsheetId
 			}
 
-			/// Fetch names on sheet
-			namesRange := fmt.Sprintf("%s!B3:B", relevantSheet)
-			resp, err := sheetsService.Spreadsheets.Values.Get(wakasheets.SpreadsheetId, namesRange).MajorDimension("COLUMNS").Do()
+			ssheet, err := sheetsService.Spreadsheets.Get(spreadsheetId).Context(ctx).Do()
 
 			if err != nil {
-				return fmt.Errorf("error retrieving usernames on sheet: %w", err)
+				return fmt.Errorf("error retrieving spreadsheet: %w", wakasheets.WrapError(ctx, err, spreadsheetId))
 			}
 
-			var namesOnSheet []string
+			var logged, declined int
+			var results []*logResult
 
-			if len(resp.Values) == 0 {
-				fmt.Println("No username data found.")
-				return nil
-			} else {
-				for _, row := range resp.Values {
-					for _, v := range row {
+			for _, p := range periods {
 
-						v := v.(string)
+				if p.placement.CrossMonth {
+					fmt.Fprintf(opts.Messages, "%s spans two months, logging to %s (week rule: %s).\n", p, p.placement, rule)
+				}
+
+				tab, err := layout.ResolveTab(ssheet, p.placement.Date())
 
-						name := strings.TrimSpace(v)
+				if err != nil {
 
-						namesOnSheet = append(namesOnSheet, name)
+					if !errors.Is(err, wakasheets.ErrTabNotFound) || !layout.Tabs.Create {
+						return err
+					}
 
+					if opts.DryRun {
+						fmt.Fprintf(opts.Messages, "Tab %q would be created from template %q.\n", layout.TabTitle(p.placement.Date()), layout.Tabs.Template)
+						continue
 					}
+
+					tab, err = layout.CreateTab(ctx, sheetsService, ssheet, p.placement.Date())
+
+					if err != nil {
+						return err
+					}
+
+					fmt.Fprintf(opts.Messages, "Created tab %q from template %q.\n", tab.Title, layout.Tabs.Template)
+
 				}
-			}
 
-			// TODO Continue as <name>?
-			form := huh.NewForm(
-				huh.NewGroup(
-					huh.NewInput().
-						Title("Enter your name (as seen on the Google Sheets document - case sensitive)").
-						Placeholder("Enter Name...").
-						Value(&username).
-						Suggestions(namesOnSheet).
-						Validate(func(value string) error {
-							if len(value) == 0 {
-								return fmt.Errorf("Your name is required to proceed.")
-							}
-
-							if !slices.Contains(namesOnSheet, value) {
-								return fmt.Errorf("Name not found on sheet.")
-							}
-							return nil
-						}).WithTheme(huh.ThemeBase()),
-				),
-			)
-
-			err = form.RunWithContext(ctx)
+				err = layout.ValidateSheet(tab)
 
-			if err != nil {
-				return fmt.Errorf("error getting username: %w", err)
-			}
+				if err != nil {
+					return err
+				}
+
+				relevantSheet := tab.Title
+				relevantSheetId := tab.SheetId
 
-			var rowIndex int
+				/// Fetch names on sheet
+				namesRange := layout.NamesRange(relevantSheet)
+				resp, err := sheetsService.Spreadsheets.Values.Get(spreadsheetId, namesRange).MajorDimension("COLUMNS").Context(ctx).Do()
 
-			for i, name := range namesOnSheet {
-				if name == username {
-					rowIndex = i + 3 // row cells start counting from 1 (so +1), then ignore first two rows (they are headers/don't contain user's data)
-					break
+				if err != nil {
+					return fmt.Errorf("error retrieving usernames on sheet: %w", wakasheets.WrapError(ctx, err, spreadsheetId))
 				}
-			}
 
-			err = updateSheet(ctx, app, relevantSheet, rowIndex, relevantSheetId)
+				var namesOnSheet []string
 
-			if err != nil {
+				if len(resp.Values) == 0 {
+					return &wakalog.NameNotFoundError{Err: fmt.Errorf("no names found in %s", namesRange)}
+				} else {
+					for _, row := range resp.Values {
+						for _, v := range row {
 
-				if errors.Is(err, errNoProjects) {
-					fmt.Println("No projects data found for period. Don't have WakaTime? Install WakaTime plugin on your IDE to get started.")
-					return nil
+							v := v.(string)
+
+							name := strings.TrimSpace(v)
+
+							namesOnSheet = append(namesOnSheet, name)
+
+						}
+					}
+				}
+
+				username, err := getUsername(ctx, opts, config, namesOnSheet)
+
+				if err != nil {
+					return err
+				}
+
+				var rowIndex int
+
+				for i, name := range namesOnSheet {
+					if name == username {
+						rowIndex = i + layout.FirstRow() // ignore header rows (they don't contain user's data)
+						break
+					}
+				}
+
+				blockRange, err := layout.BlockRange(relevantSheet, p.placement.Block, rowIndex)
+
+				if err != nil {
+					return err
 				}
-				return fmt.Errorf("error updating sheet: %w", err)
+
+				if opts.Backfill {
+
+					isLogged, err := isBlockLogged(ctx, app, layout, spreadsheetId, blockRange)
+
+					if err != nil {
+						return err
+					}
+
+					if isLogged {
+						continue
+					}
+
+				}
+
+				if len(periods) > 1 {
+					fmt.Fprintf(opts.Messages, "Logging %s\n", p)
+				}
+
+				result, err := updateSheet(ctx, app, opts, layout, spreadsheetId, p, blockRange)
+
+				linkToSheet := fmt.Sprintf("https://docs.google.com/spreadsheets/d/%s/edit?gid=%d#gid=%d", spreadsheetId, relevantSheetId, relevantSheetId)
+
+				if result != nil {
+					result.SpreadsheetURL = linkToSheet
+					results = append(results, result)
+				}
+
+				if err != nil {
+
+					if errors.Is(err, errNoProjects) {
+						fmt.Fprintln(opts.Messages, "No projects data found for period. Don't have WakaTime? Install WakaTime plugin on your IDE to get started.")
+						continue
+					}
+
+					if errors.Is(err, errNoActivity) {
+						fmt.Fprintln(opts.Messages, "No activity found on the selected projects for period.")
+						continue
+					}
+
+					if errors.Is(err, wakalog.ErrCancelled) {
+						declined++
+						continue
+					}
+
+					if errors.Is(err, errNotWritten) {
+						continue
+					}
+					return fmt.Errorf("error updating sheet: %w", err)
+				}
+
+				logged++
+
+				fmt.Fprintf(opts.Messages, "Sheet updated successfully :)\nView sheet %s.\n", termlink.ColorLink("here", linkToSheet, "blue"))
+
 			}
 
-			linkToSheet := fmt.Sprintf("https://docs.google.com/spreadsheets/d/%s/edit?gid=%d#gid=%d", wakasheets.SpreadsheetId, relevantSheetId, relevantSheetId)
+			if len(results) > 0 || cmdutil.IsStructuredOutput(app.Output) {
 
-			fmt.Printf("Sheet updated successfully :)\nView sheet %s.\n", termlink.ColorLink("here", linkToSheet, "blue"))
+				if results == nil {
+					results = []*logResult{} // print an empty list rather than null
+				}
 
-			return nil
+				err = cmdutil.PrintOutput(os.Stdout, app.Output, results, func(w io.Writer) error {
+					return printLogResults(w, results)
+				})
+
+				if err != nil {
+					return err
+				}
+
+			}
+
+			switch {
+			case logged > 0 || opts.DryRun:
+				return nil
+			case declined > 0:
+				return fmt.Errorf("%w: existing values were not overwritten", wakalog.ErrCancelled)
+			case opts.Backfill:
+				return fmt.Errorf("%w: every week of the month is already logged", wakalog.ErrNothingToLog)
+			default:
+				return fmt.Errorf("%w: no activity found for period", wakalog.ErrNothingToLog)
+			}
 
 		},
 	}
 
+	cmd.Flags().StringVar(&opts.Name, "name", "", "Your name as seen on the Google Sheets document")
+	cmd.Flags().StringSliceVar(&opts.Projects, "projects", nil, "Comma separated list of projects to log activity from")
+	cmd.Flags().BoolVar(&opts.AllProjects, "all-projects", false, "Log activity from all projects worked on during the period")
+	cmd.Flags().StringVar(&opts.Project, "project", "", "Log activity from a single project, filtered by WakaTime")
+	cmd.Flags().StringSliceVar(&opts.Branches, "branch", nil, "Comma separated list of branches or globs of --project to log activity from e.g. main,release/*")
+	cmd.Flags().BoolVarP(&opts.Yes, "yes", "y", false, "Use preferred projects from config and overwrite existing values without prompting")
+	cmd.Flags().StringVar(&opts.LayoutFile, "layout", "", "Path to a JSON file describing the sheet layout (overrides config)")
+	cmd.Flags().BoolVar(&opts.CreateTab, "create-tab", false, "Create a missing month tab by duplicating the layout's template tab")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Preview the values that would be written without updating the sheet")
+	cmd.Flags().BoolVar(&opts.NoInput, "no-input", false, "Disable prompts and fail when a required value is missing")
+
+	cmd.Flags().StringVar(&opts.Week, "week", "", "ISO week to log e.g. 2026-W38")
+	cmd.Flags().StringVar(&opts.From, "from", "", "First day of the period to log e.g. 2026-09-14")
+	cmd.Flags().StringVar(&opts.To, "to", "", "Last day of the period to log e.g. 2026-09-18")
+	cmd.Flags().IntVar(&opts.WeeksAgo, "weeks-ago", 0, "Log the week N weeks before the current week")
+	cmd.Flags().BoolVar(&opts.Backfill, "backfill", false, "Log every week of the month not yet logged")
+
+	cmd.MarkFlagsMutuallyExclusive("projects", "all-projects", "project")
+	cmd.MarkFlagsMutuallyExclusive("week", "from", "weeks-ago", "backfill")
+	cmd.MarkFlagsRequiredTogether("from", "to")
+
 	return cmd
 }
 
-func checkForWakaTimeAPIKey() (string, error) {
+// getUsername resolves the user's name from the --name flag or config, prompting for it when missing.
+// Prompted names are saved to config for subsequent runs.
+func getUsername(ctx context.Context, opts *LogOptions, config *wakalog.Config, namesOnSheet []string) (string, error) {
 
-	var apiKey string
+	if opts.Name != "" {
 
-	if err := wakatime.GetAPIKey(&apiKey); err != nil {
-
-		if errors.Is(err, keyring.ErrNotFound) {
-			return "", wakalog.ErrWakaTimeAPIKeyNotFound
+		if !slices.Contains(namesOnSheet, opts.Name) {
+			return "", &wakalog.NameNotFoundError{Err: fmt.Errorf("name %q not found on sheet", opts.Name)}
 		}
 
-		return "", wakalog.ErrGeneric
+		return opts.Name, nil
 
-	} else {
+	}
 
-		if len(strings.TrimSpace(apiKey)) == 0 {
+	if slices.Contains(namesOnSheet, config.Username) {
+		return config.Username, nil
+	}
 
-			return "", wakalog.ErrWakaTimeAPIKeyNotFound
+	if !opts.Interactive {
 
+		if config.Username != "" {
+			return "", &wakalog.NameNotFoundError{Err: fmt.Errorf("configured name %q not found on sheet: use --name to specify your name", config.Username)}
 		}
-	}
 
-	return apiKey, nil
+		return "", &wakalog.FlagError{Err: errors.New("name is required when not running interactively: use --name or <wakalog config set username>")}
 
-}
+	}
+
+	if config.Username != "" {
+		fmt.Fprintf(opts.Messages, "Configured name %q not found on sheet.\n", config.Username)
+	}
 
-func getRelevantStartAndEndDate() (time.Time, time.Time) {
+	var username string
 
-	now := time.Now()
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Enter your name (as seen on the Google Sheets document - case sensitive)").
+				Placeholder("Enter Name...").
+				Value(&username).
+				Suggestions(namesOnSheet).
+				Validate(func(value string) error {
+					if len(value) == 0 {
+						return fmt.Errorf("Your name is required to proceed.")
+					}
 
-	currentYear, currentWeek := now.ISOWeek()
+					if !slices.Contains(namesOnSheet, value) {
+						return fmt.Errorf("Name not found on sheet.")
+					}
+					return nil
+				}).WithTheme(huh.ThemeBase()),
+		),
+	)
 
-	dayOfWeek := int(now.Weekday())
+	err := form.RunWithContext(ctx)
 
-	var relevantWeekOffset int
+	if err != nil {
+		return "", fmt.Errorf("error getting username: %w", err)
+	}
 
-	/// Relevant days to work with is working days of the week (Mon - Fri)
-	/// If it's weekend (Saturday, or Sunday), work with current week (Mon - Fri stats is available)
-	/// Else, 1 week needs to be offset from current week in order to work with last week's data as the current working week is not yet over
+	config.Username = username
 
-	if dayOfWeek == 6 || dayOfWeek == 0 {
-		relevantWeekOffset = 0
+	err = config.Save()
 
-	} else {
-		relevantWeekOffset = 1
+	if err != nil {
+		return "", fmt.Errorf("error saving config: %w", err)
 	}
 
-	relevantWeek := currentWeek - relevantWeekOffset
+	return username, nil
 
-	startDate := timex.WeekStart(currentYear, relevantWeek)
-	endDate := startDate.AddDate(0, 0, 4)
+}
 
-	return startDate, endDate
+// updateSheet writes the summary of p to writeRange. The returned result is nil when nothing was computed, e.g. no projects were worked on,
+// and has Written false when values were not written.
+func updateSheet(ctx context.Context, app *wakalog.Application, opts *LogOptions, layout *wakasheets.Layout, spreadsheetId string, p period, writeRange string) (*logResult, error) {
 
-}
+	config := app.Config
 
-func updateSheet(ctx context.Context, app *wakalog.Application, sheet string, rowIndex int, sheetId int64) error {
+	startDate, endDate := p.start, p.end
 
-	startDate, endDate := getRelevantStartAndEndDate()
+	summariesOpts := &wakatime.SummariesOptions{Timezone: app.Timezone}
 
-	summaries, err := app.WakaTime.GetSummaries(ctx, startDate, endDate)
+	if opts.Project != "" {
+		summariesOpts.Project = opts.Project
+		summariesOpts.Branches = literalBranches(opts.Branches)
+	}
+
+	summaries, err := app.WakaTime.GetSummaries(ctx, startDate, endDate, summariesOpts)
 
 	if err != nil {
-		return fmt.Errorf("error getting summaries: %w", err)
+		return nil, fmt.Errorf("error getting summaries: %w", err)
 	}
 
-	var projectOptions []huh.Option[string]
-	var projects []string
 	var selectedProjects []string
 
-	// Loop over period/days e.g. Mon-Fri
-	for _, data := range summaries.Data {
+	if opts.Project != "" {
+
+		selectedProjects = []string{opts.Project}
 
-		for _, project := range data.Projects {
+	} else {
 
-			name := project.Name
+		projects := stats.ProjectNames(summaries)
 
-			// Get unique list of projects worked on during period
-			if !slices.Contains(projects, name) {
-				projects = append(projects, name)
-				projectOptions = append(projectOptions, huh.NewOption(name, name))
+		if len(projects) == 0 {
+
+			return nil, errNoProjects
+
+		}
+
+		selectedProjects, err = selectProjects(ctx, opts, config, projects)
+
+		if err != nil {
+			return nil, err
+		}
+
+	}
+
+	valuesRequest := &sheets.BatchUpdateValuesRequest{
+		ValueInputOption: "RAW",
+	}
+
+	var valueRange sheets.ValueRange
+
+	/// Branches matching --branch patterns, used to scope breakdowns to the same branches
+	var matchedBranches []string
+
+	var days []stats.Day
+
+	if opts.Project != "" {
+
+		/// Summaries are already filtered to the project by WakaTime
+		for i, data := range summaries.Data {
+
+			day := stats.Day{Date: stats.DayDate(data, startDate, i), Projects: map[string]time.Duration{}}
+
+			day.Total = branchTimeForDay(data, opts.Branches, &matchedBranches)
+
+			if day.Total > 0 {
+				day.Projects[opts.Project] = day.Total
 			}
 
+			days = append(days, day)
+
 		}
+
+	} else {
+
+		days = stats.DaysFromSummaries(summaries, startDate, selectedProjects)
+
 	}
 
-	if len(projectOptions) == 0 {
+	report := stats.NewWeeklyReport(days)
 
-		return errNoProjects
+	if !report.HasActivity() {
+		return nil, errNoActivity
+	}
 
+	metrics := map[string]interface{}{
+		wakasheets.MetricDailyAverage:  report.DailyAverage.Round(time.Second).String(),
+		wakasheets.MetricMostActiveDay: days[report.MostActiveDay].Date.Format("Mon 2 Jan"),
+		wakasheets.MetricTotal:         report.Total.Round(time.Second).String(),
 	}
 
-	form := huh.NewForm(
-		huh.NewGroup(
-			huh.NewMultiSelect[string]().
-				Title("Select projects to get weekly activity from").
-				Options(
-					projectOptions...,
-				).
-				Value(&selectedProjects).Validate(func(options []string) error {
-				if len(options) == 0 {
-					return fmt.Errorf("Select a project (using x or spacebar) to proceed.")
-				}
+	if layout.HasBreakdown() {
 
-				return nil
-			}),
-		),
-	)
+		breakdownMetrics, err := getBreakdownMetrics(ctx, app, startDate, endDate, selectedProjects, matchedBranches, layout.TopCount())
 
-	err = form.RunWithContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		maps.Copy(metrics, breakdownMetrics)
 
-	if err != nil {
-		return fmt.Errorf("error generating project options: %w", err)
 	}
 
-	startColumns := []string{"C", "G", "K", "O", "S"} // representing 5 possible weeks in a month
+	result := &logResult{
+		Period: periodResult{
+			Start: startDate.Format(dateLayout),
+			End:   endDate.Format(dateLayout),
+			Tab:   layout.TabTitle(p.placement.Date()),
+			Week:  p.placement.Block + 1,
+		},
+		Projects:      selectedProjects,
+		Branches:      matchedBranches,
+		DailyAverage:  metrics[wakasheets.MetricDailyAverage].(string),
+		MostActiveDay: days[report.MostActiveDay].Date.Format(dateLayout),
+		Total:         metrics[wakasheets.MetricTotal].(string),
+		TotalSeconds:  report.Total.Seconds(),
+		Range:         writeRange,
+	}
 
-	relevantWeek := startDate.Day() / 7
+	for _, day := range days {
+		result.Days = append(result.Days, newDayResult(day.Date, day.Total))
+	}
 
-	startColumn := startColumns[relevantWeek]
+	data := make([]interface{}, len(layout.Metrics))
 
-	writeRange := fmt.Sprintf("%s!%s%d", sheet, startColumn, rowIndex)
+	for i, metric := range layout.Metrics {
+
+		if metric == "" {
+			data[i] = nil // leave cell untouched
+			continue
+		}
+
+		data[i] = metrics[metric]
 
-	valuesRequest := &sheets.BatchUpdateValuesRequest{
-		ValueInputOption: "RAW",
 	}
+	valueRange.Values = append(valueRange.Values, data)
+	valueRange.Range = writeRange
 
-	var valueRange sheets.ValueRange
+	valuesRequest.Data = append(valuesRequest.Data, &valueRange)
 
-	var daysWorked int
-	var totalTimePerDay []time.Duration
-	var mostActiveDay int
-	var mostActiveDuration time.Duration
+	currentValues, err := getBlockValues(ctx, app, spreadsheetId, writeRange)
 
-	// Loop over period/days e.g. Mon-Fri
-	for i, data := range summaries.Data {
+	if err != nil {
+		return nil, err
+	}
 
-		var totalTimeForDay time.Duration
+	overwrite := hasValues(layout, currentValues)
 
-		for _, project := range data.Projects {
+	if opts.DryRun || overwrite {
+		printPreview(opts.Messages, layout, writeRange, currentValues, data)
+	}
 
-			projectName := project.Name
+	if opts.DryRun {
+		return result, errNotWritten
+	}
 
-			if slices.Contains(selectedProjects, projectName) {
+	if overwrite && !opts.Yes {
 
-				totalTime, _ := time.ParseDuration(fmt.Sprintf("%dh%dm%ds", project.Hours, project.Minutes, project.Seconds))
+		if !opts.Interactive {
+			return nil, &wakalog.FlagError{Err: fmt.Errorf("%s already has values: use --yes to overwrite them", writeRange)}
+		}
 
-				totalTimeForDay += totalTime
+		confirmed, err := cmdutil.PromptForConfirmation(ctx, "Existing values will be overwritten. Do you want to continue?")
 
-			}
+		if err != nil {
+			return nil, err
+		}
 
+		if !confirmed {
+			return result, wakalog.ErrCancelled
 		}
 
-		if i == 0 {
-			mostActiveDay = i
-			mostActiveDuration = totalTimeForDay
-		} else {
-			if totalTimeForDay > mostActiveDuration {
-				mostActiveDay = i
-				mostActiveDuration = totalTimeForDay
+	}
+
+	_, err = app.Sheets.Spreadsheets.Values.BatchUpdate(spreadsheetId, valuesRequest).Context(ctx).Do()
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to write data on sheet: %w", wakasheets.WrapError(ctx, err, spreadsheetId))
+	}
+
+	result.Written = true
+
+	return result, nil
+}
+
+// getLayout returns the sheet layout from the --layout flag, falling back to config, and validates it
+func getLayout(opts *LogOptions, config *wakalog.Config) (*wakasheets.Layout, error) {
+
+	var layout *wakasheets.Layout
+	var err error
+
+	if opts.LayoutFile != "" {
+		layout, err = wakasheets.LoadLayout(opts.LayoutFile)
+	} else {
+		layout, err = config.SheetLayout()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.CreateTab {
+		layout.Tabs.Create = true
+	}
+
+	err = layout.Validate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return layout, nil
+
+}
+
+// selectProjects resolves the projects to log activity from. In order of precedence: the --projects and --all-projects flags,
+// preferred projects from config when --yes is set or prompts are disabled, and finally a prompt with preferred projects preselected.
+// Prompted selections are saved to config as preferred projects.
+func selectProjects(ctx context.Context, opts *LogOptions, config *wakalog.Config, projects []string) ([]string, error) {
+
+	if opts.AllProjects {
+		return projects, nil
+	}
+
+	if len(opts.Projects) > 0 {
+
+		for _, name := range opts.Projects {
+			if !slices.Contains(projects, name) {
+				fmt.Fprintf(opts.Messages, "No activity found for project %q during period.\n", name)
 			}
 		}
 
-		totalTimePerDay = append(totalTimePerDay, totalTimeForDay)
+		return opts.Projects, nil
+
+	}
+
+	var preferredProjects []string
+
+	for _, name := range projects {
+		if slices.Contains(config.Projects, name) {
+			preferredProjects = append(preferredProjects, name)
+		}
 	}
 
-	var cummulativeTotalTime time.Duration
+	if opts.Yes || !opts.Interactive {
 
-	for _, totalTime := range totalTimePerDay {
+		if len(preferredProjects) > 0 {
+			return preferredProjects, nil
+		}
 
-		// Skip days where user had no coding activity.
-		// Daily Average computation according to WakaTime ignores days of no activity
-		if totalTime <= time.Duration(0) {
-			continue
+		if !opts.Interactive {
+			return nil, &wakalog.FlagError{Err: errors.New("no preferred projects worked on during period: use --projects or --all-projects")}
 		}
 
-		daysWorked += 1
-		cummulativeTotalTime += totalTime
+	}
+
+	var projectOptions []huh.Option[string]
+	var selectedProjects []string
 
+	for _, name := range projects {
+		projectOptions = append(projectOptions, huh.NewOption(name, name).Selected(slices.Contains(preferredProjects, name)))
 	}
 
-	dailyAverage := cummulativeTotalTime.Hours() / float64(daysWorked)
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewMultiSelect[string]().
+				Title("Select projects to get weekly activity from").
+				Options(
+					projectOptions...,
+				).
+				Value(&selectedProjects).Validate(func(options []string) error {
+				if len(options) == 0 {
+					return fmt.Errorf("Select a project (using x or spacebar) to proceed.")
+				}
+
+				return nil
+			}),
+		),
+	)
+
+	err := form.RunWithContext(ctx)
 
-	data := []interface{}{time.Duration(dailyAverage * float64(time.Hour)).Round(time.Second).String(), startDate.AddDate(0, 0, mostActiveDay).Format("Mon 1 Jan"), cummulativeTotalTime.Round(time.Second).String()}
-	valueRange.Values = append(valueRange.Values, data)
-	valueRange.Range = writeRange
+	if err != nil {
+		return nil, fmt.Errorf("error generating project options: %w", err)
+	}
 
-	valuesRequest.Data = append(valuesRequest.Data, &valueRange)
+	/// Remember selection as preferred projects, keeping preferred projects not worked on during period
+	otherProjects := slices.DeleteFunc(slices.Clone(config.Projects), func(name string) bool {
+		return slices.Contains(projects, name)
+	})
+	config.Projects = append(otherProjects, selectedProjects...)
 
-	_, err = app.Sheets.Spreadsheets.Values.BatchUpdate(wakasheets.SpreadsheetId, valuesRequest).Do()
+	err = config.Save()
 
 	if err != nil {
-		return fmt.Errorf("unable to write data on sheet: %w", err)
+		return nil, fmt.Errorf("error saving config: %w", err)
 	}
 
-	return nil
+	return selectedProjects, nil
+
 }
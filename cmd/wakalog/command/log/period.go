@@ -0,0 +1,118 @@
+package log
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Youngtard/wakalog/calendar"
+	"github.com/Youngtard/wakalog/wakalog"
+)
+
+const dateLayout = "2006-01-02"
+
+// period is a range of days logged as one week block on the sheet
+type period struct {
+	start time.Time
+	end   time.Time
+	// placement is the month tab and week block the period is logged to
+	placement calendar.Placement
+}
+
+func newPeriod(start, end time.Time, rule calendar.Rule) period {
+	return period{start: start, end: end, placement: calendar.Place(start, end, rule)}
+}
+
+func (p period) String() string {
+	return fmt.Sprintf("%s - %s", p.start.Format("Mon 2 Jan 2006"), p.end.Format("Mon 2 Jan 2006"))
+}
+
+// getPeriods resolves the periods to log from the period flags, defaulting to the last working week.
+// With --backfill, every complete week placed in the same month as the last working week is returned; weeks already logged are skipped later on.
+func getPeriods(opts *LogOptions, now time.Time, workingDays int, rule calendar.Rule) ([]period, error) {
+
+	switch {
+	case opts.Week != "":
+
+		start, err := calendar.ParseISOWeek(opts.Week)
+
+		if err != nil {
+			return nil, &wakalog.FlagError{Err: fmt.Errorf("invalid --week: %w", err)}
+		}
+
+		return []period{newPeriod(start, start.AddDate(0, 0, workingDays-1), rule)}, nil
+
+	case opts.From != "":
+
+		start, err := time.Parse(dateLayout, opts.From)
+
+		if err != nil {
+			return nil, &wakalog.FlagError{Err: fmt.Errorf("invalid --from %q: expected date e.g. 2026-09-14", opts.From)}
+		}
+
+		end, err := time.Parse(dateLayout, opts.To)
+
+		if err != nil {
+			return nil, &wakalog.FlagError{Err: fmt.Errorf("invalid --to %q: expected date e.g. 2026-09-18", opts.To)}
+		}
+
+		if end.Before(start) {
+			return nil, &wakalog.FlagError{Err: fmt.Errorf("--to %s is before --from %s", opts.To, opts.From)}
+		}
+
+		if !calendar.WeekStart(start).Equal(calendar.WeekStart(end)) {
+			return nil, &wakalog.FlagError{Err: fmt.Errorf("--from %s and --to %s must be within the same week", opts.From, opts.To)}
+		}
+
+		return []period{newPeriod(start, end, rule)}, nil
+
+	case opts.WeeksAgo != 0:
+
+		start, err := calendar.WeeksAgo(now, opts.WeeksAgo)
+
+		if err != nil {
+			return nil, &wakalog.FlagError{Err: fmt.Errorf("invalid --weeks-ago %d: %w", opts.WeeksAgo, err)}
+		}
+
+		return []period{newPeriod(start, start.AddDate(0, 0, workingDays-1), rule)}, nil
+
+	case opts.Backfill:
+
+		lastStart, lastEnd := getRelevantStartAndEndDate(now, workingDays)
+
+		last := newPeriod(lastStart, lastEnd, rule)
+
+		periods := []period{last}
+
+		/// Walk back from the last working week to the first week placed in the same month
+		for start := lastStart.AddDate(0, 0, -7); ; start = start.AddDate(0, 0, -7) {
+
+			p := newPeriod(start, start.AddDate(0, 0, workingDays-1), rule)
+
+			if p.placement.Year != last.placement.Year || p.placement.Month != last.placement.Month {
+				break
+			}
+
+			periods = append([]period{p}, periods...)
+
+		}
+
+		return periods, nil
+
+	default:
+
+		start, end := getRelevantStartAndEndDate(now, workingDays)
+
+		return []period{newPeriod(start, end, rule)}, nil
+
+	}
+
+}
+
+func getRelevantStartAndEndDate(now time.Time, workingDays int) (time.Time, time.Time) {
+
+	startDate := calendar.LastWorkingWeek(now)
+	endDate := startDate.AddDate(0, 0, workingDays-1)
+
+	return startDate, endDate
+
+}
@@ -0,0 +1,56 @@
+package log
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Youngtard/wakalog/calendar"
+)
+
+func TestGetPeriodsAcrossDST(t *testing.T) {
+
+	london, err := time.LoadLocation("Europe/London")
+
+	if err != nil {
+		t.Skip("Europe/London not available:", err)
+	}
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		opts      LogOptions
+		wantStart string
+		wantEnd   string
+	}{
+		{name: "saturday after clocks go forward", now: time.Date(2026, time.April, 4, 0, 30, 0, 0, london), wantStart: "2026-03-30", wantEnd: "2026-04-03"},
+		{name: "same instant in UTC is friday", now: time.Date(2026, time.April, 4, 0, 30, 0, 0, london).UTC(), wantStart: "2026-03-23", wantEnd: "2026-03-27"},
+		{name: "saturday before clocks go back", now: time.Date(2026, time.October, 24, 0, 30, 0, 0, london), wantStart: "2026-10-19", wantEnd: "2026-10-23"},
+		{name: "saturday after clocks go back", now: time.Date(2026, time.October, 31, 0, 30, 0, 0, london), wantStart: "2026-10-26", wantEnd: "2026-10-30"},
+		{name: "weekday after clocks go back", now: time.Date(2026, time.October, 26, 0, 30, 0, 0, london), wantStart: "2026-10-19", wantEnd: "2026-10-23"},
+		{name: "weeks ago across clocks going forward", now: time.Date(2026, time.April, 4, 0, 30, 0, 0, london), opts: LogOptions{WeeksAgo: 1}, wantStart: "2026-03-23", wantEnd: "2026-03-27"},
+		{name: "weeks ago across clocks going back", now: time.Date(2026, time.October, 31, 0, 30, 0, 0, london), opts: LogOptions{WeeksAgo: 1}, wantStart: "2026-10-19", wantEnd: "2026-10-23"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			periods, err := getPeriods(&tt.opts, tt.now, 5, calendar.RuleFirstMonday)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(periods) != 1 {
+				t.Fatalf("got %d periods, want 1", len(periods))
+			}
+
+			start, end := periods[0].start.Format(dateLayout), periods[0].end.Format(dateLayout)
+
+			if start != tt.wantStart || end != tt.wantEnd {
+				t.Errorf("period = %s - %s, want %s - %s", start, end, tt.wantStart, tt.wantEnd)
+			}
+
+		})
+	}
+
+}
@@ -0,0 +1,102 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	wakasheets "github.com/Youngtard/wakalog/sheets"
+	"github.com/Youngtard/wakalog/wakalog"
+)
+
+// getBlockValues returns the current values within blockRange, a single row
+func getBlockValues(ctx context.Context, app *wakalog.Application, spreadsheetId string, blockRange string) ([]interface{}, error) {
+
+	resp, err := app.Sheets.Spreadsheets.Values.Get(spreadsheetId, blockRange).Context(ctx).Do()
+
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving logged values on sheet: %w", wakasheets.WrapError(ctx, err, spreadsheetId))
+	}
+
+	if len(resp.Values) == 0 {
+		return nil, nil
+	}
+
+	return resp.Values[0], nil
+
+}
+
+// isBlockLogged reports whether any metric cell within blockRange has a value
+func isBlockLogged(ctx context.Context, app *wakalog.Application, layout *wakasheets.Layout, spreadsheetId string, blockRange string) (bool, error) {
+
+	values, err := getBlockValues(ctx, app, spreadsheetId, blockRange)
+
+	if err != nil {
+		return false, err
+	}
+
+	return hasValues(layout, values), nil
+
+}
+
+// hasValues reports whether any metric cell has a value, ignoring cells left untouched by the layout
+func hasValues(layout *wakasheets.Layout, values []interface{}) bool {
+
+	for i, v := range values {
+
+		if i < len(layout.Metrics) && layout.Metrics[i] == "" {
+			continue
+		}
+
+		if v != nil && strings.TrimSpace(fmt.Sprint(v)) != "" {
+			return true
+		}
+	}
+
+	return false
+
+}
+
+// printPreview prints the target range along with its current values and the values to be written
+func printPreview(out io.Writer, layout *wakasheets.Layout, writeRange string, currentValues []interface{}, newValues []interface{}) {
+
+	fmt.Fprintf(out, "Range: %s\n", writeRange)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	headers := make([]string, len(layout.Metrics))
+
+	for i, metric := range layout.Metrics {
+		headers[i] = wakasheets.MetricLabel(metric)
+	}
+
+	fmt.Fprintf(w, "\t%s\n", strings.Join(headers, "\t"))
+	fmt.Fprintf(w, "Current\t%s\n", formatRow(currentValues, len(newValues)))
+	fmt.Fprintf(w, "New\t%s\n", formatRow(newValues, len(newValues)))
+
+	w.Flush()
+
+}
+
+// formatRow joins values with tabs, padding to size with "-" for empty cells
+func formatRow(values []interface{}, size int) string {
+
+	cells := make([]string, size)
+
+	for i := range cells {
+
+		cells[i] = "-"
+
+		if i < len(values) && values[i] != nil {
+			if v := strings.TrimSpace(fmt.Sprint(values[i])); v != "" {
+				cells[i] = v
+			}
+		}
+
+	}
+
+	return strings.Join(cells, "\t")
+
+}
@@ -0,0 +1,80 @@
+package log
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+// logResult is the outcome of logging a period, printed with --output
+type logResult struct {
+	Period         periodResult `json:"period" yaml:"period"`
+	Projects       []string     `json:"projects" yaml:"projects"`
+	Branches       []string     `json:"branches,omitempty" yaml:"branches,omitempty"`
+	Days           []dayResult  `json:"days" yaml:"days"`
+	DailyAverage   string       `json:"daily_average" yaml:"daily_average"`
+	MostActiveDay  string       `json:"most_active_day" yaml:"most_active_day"`
+	Total          string       `json:"total" yaml:"total"`
+	TotalSeconds   float64      `json:"total_seconds" yaml:"total_seconds"`
+	SpreadsheetURL string       `json:"spreadsheet_url" yaml:"spreadsheet_url"`
+	// Range is the range written, or that would be written on a dry run
+	Range string `json:"range" yaml:"range"`
+	// Written reports whether values were written on the sheet i.e. not a dry run or a declined overwrite
+	Written bool `json:"written" yaml:"written"`
+}
+
+type periodResult struct {
+	Start string `json:"start" yaml:"start"`
+	End   string `json:"end" yaml:"end"`
+	Tab   string `json:"tab" yaml:"tab"`
+	// Week is the one based week block within the tab
+	Week int `json:"week" yaml:"week"`
+}
+
+type dayResult struct {
+	Date     string  `json:"date" yaml:"date"`
+	Duration string  `json:"duration" yaml:"duration"`
+	Seconds  float64 `json:"seconds" yaml:"seconds"`
+}
+
+func newDayResult(date time.Time, duration time.Duration) dayResult {
+	return dayResult{Date: date.Format(dateLayout), Duration: duration.Round(time.Second).String(), Seconds: duration.Seconds()}
+}
+
+// printLogResults prints the time logged per day and the summary of each result
+func printLogResults(w io.Writer, results []*logResult) error {
+
+	for i, result := range results {
+
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+		fmt.Fprintf(tw, "Period\t%s - %s\n", result.Period.Start, result.Period.End)
+
+		for _, day := range result.Days {
+
+			date, _ := time.Parse(dateLayout, day.Date)
+
+			fmt.Fprintf(tw, "%s\t%s\n", date.Format("Mon 2 Jan"), day.Duration)
+
+		}
+
+		fmt.Fprintf(tw, "Daily Average\t%s\n", result.DailyAverage)
+		fmt.Fprintf(tw, "Most Active Day\t%s\n", result.MostActiveDay)
+		fmt.Fprintf(tw, "Total\t%s\n", result.Total)
+
+		err := tw.Flush()
+
+		if err != nil {
+			return err
+		}
+
+	}
+
+	return nil
+
+}
@@ -1,13 +1,24 @@
 package command
 
 import (
+	"strings"
+
+	"github.com/Youngtard/wakalog/pkg/cmdutil"
 	"github.com/Youngtard/wakalog/wakalog"
+	"github.com/Youngtard/wakalog/wakatime"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 func NewRootCommand(app *wakalog.Application) *cobra.Command {
 	cobra.OnInitialize()
 
+	var apiURL string
+	var timezone string
+	var output string
+	var debug bool
+	var traceFile string
+
 	cmd := &cobra.Command{
 		Use:           "wakalog <command> <subcommand> [flags]",
 		Short:         "Log your WakaTime summaries",
@@ -15,13 +26,73 @@ func NewRootCommand(app *wakalog.Application) *cobra.Command {
 		SilenceUsage:  true,
 		SilenceErrors: true,
 
-		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+
+			/// Cobra validates flag groups after PreRunE, too late to skip prompts and authorization, and not as a flag error
+			err := cmd.ValidateFlagGroups()
+
+			if err != nil {
+				return &wakalog.FlagError{Err: err}
+			}
+
+			err = app.ConfigureLogging(debug, traceFile)
+
+			if err != nil {
+				return err
+			}
+
+			err = app.LoadConfig()
+
+			if err != nil {
+				return err
+			}
+
+			err = app.ResolveWakaTimeURL(apiURL)
+
+			if err != nil {
+				return err
+			}
+
+			err = app.ResolveTimezone(timezone)
+
+			if err != nil {
+				return err
+			}
+
+			err = cmdutil.ValidateOutputFormat(output)
+
+			if err != nil {
+				return &wakalog.FlagError{Err: err}
+			}
+
+			app.Output = output
+
+			app.Logger.Debug("resolved settings", "command", cmd.CommandPath(), "config", app.Config.Path(), "api_url", app.WakaTimeURL, "timezone", app.Location, "output", app.Output)
+
+			return nil
 
 		},
 		// Version:               fmt.Sprintf("%s, build %s", version.Version, version.GitCommit),
 
 	}
 
+	cmd.PersistentFlags().StringVar(&apiURL, "api-url", "", "Base URL of a WakaTime compatible API (default from $WAKATIME_API_URL, config or "+wakatime.DefaultBaseURL+")")
+
+	cmd.PersistentFlags().StringVar(&timezone, "tz", "", "IANA timezone of your days e.g. Africa/Lagos (default from config or system timezone)")
+
+	cmd.PersistentFlags().StringVarP(&output, "output", "o", cmdutil.OutputTable, "Output format of results: "+strings.Join(cmdutil.OutputFormats(), ", "))
+
+	cmd.PersistentFlags().BoolVar(&debug, "debug", false, "Log debug messages and HTTP requests to stderr (default from $"+wakalog.DebugEnv+")")
+	cmd.PersistentFlags().StringVar(&traceFile, "trace-file", "", "Append full HTTP requests and responses to a file as JSON lines, credentials redacted")
+
+	/// --verbose is an alias of --debug
+	cmd.SetGlobalNormalizationFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
+		if name == "verbose" {
+			name = "debug"
+		}
+		return pflag.NormalizedName(name)
+	})
+
 	cmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
 
 		return &wakalog.FlagError{Err: err}
@@ -30,6 +101,31 @@ func NewRootCommand(app *wakalog.Application) *cobra.Command {
 
 	addCommands(cmd, app)
 
+	wrapArgsErrors(cmd)
+
 	return cmd
 
 }
+
+// wrapArgsErrors makes argument validation errors of cmd and its subcommands flag errors
+func wrapArgsErrors(cmd *cobra.Command) {
+
+	if validate := cmd.Args; validate != nil {
+		cmd.Args = func(cmd *cobra.Command, args []string) error {
+
+			err := validate(cmd, args)
+
+			if err != nil {
+				return &wakalog.FlagError{Err: err}
+			}
+
+			return nil
+
+		}
+	}
+
+	for _, c := range cmd.Commands() {
+		wrapArgsErrors(c)
+	}
+
+}
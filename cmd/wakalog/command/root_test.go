@@ -0,0 +1,38 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Youngtard/wakalog/wakalog"
+)
+
+func TestUsageErrors(t *testing.T) {
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "missing argument", args: []string{"config", "get"}},
+		{name: "extra argument", args: []string{"auth", "status", "extra"}},
+		{name: "mutually exclusive flags", args: []string{"auth", "logout", "--wakatime", "--google"}},
+		{name: "flags required together", args: []string{"log", "--from", "2026-10-12"}},
+		{name: "unknown flag", args: []string{"summary", "--nope"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			cmd := NewRootCommand(&wakalog.Application{})
+			cmd.SetArgs(tt.args)
+
+			err := cmd.ExecuteContext(context.Background())
+
+			if got := wakalog.ExitCode(err); got != wakalog.ExitUsage {
+				t.Errorf("ExitCode(%v) = %d, want %d", err, got, wakalog.ExitUsage)
+			}
+
+		})
+	}
+
+}
@@ -0,0 +1,84 @@
+package summary
+
+import (
+	"time"
+
+	"github.com/Youngtard/wakalog/stats"
+)
+
+// summaryResult is the summary of a week, printed with --output
+type summaryResult struct {
+	Start               string          `json:"start" yaml:"start"`
+	End                 string          `json:"end" yaml:"end"`
+	Days                []durationItem  `json:"days" yaml:"days"`
+	Projects            []durationItem  `json:"projects" yaml:"projects"`
+	DailyAverage        string          `json:"daily_average" yaml:"daily_average"`
+	DailyAverageSeconds float64         `json:"daily_average_seconds" yaml:"daily_average_seconds"`
+	Median              string          `json:"median" yaml:"median"`
+	LongestStreak       int             `json:"longest_streak" yaml:"longest_streak"`
+	MostActiveDay       string          `json:"most_active_day,omitempty" yaml:"most_active_day,omitempty"`
+	Total               string          `json:"total" yaml:"total"`
+	TotalSeconds        float64         `json:"total_seconds" yaml:"total_seconds"`
+	Previous            *previousResult `json:"previous_week" yaml:"previous_week"`
+}
+
+// previousResult is the summary of the previous week, for comparison
+type previousResult struct {
+	Start               string  `json:"start" yaml:"start"`
+	End                 string  `json:"end" yaml:"end"`
+	DailyAverage        string  `json:"daily_average" yaml:"daily_average"`
+	DailyAverageSeconds float64 `json:"daily_average_seconds" yaml:"daily_average_seconds"`
+	Total               string  `json:"total" yaml:"total"`
+	TotalSeconds        float64 `json:"total_seconds" yaml:"total_seconds"`
+}
+
+// durationItem is the time spent on a day (Date) or a project (Name)
+type durationItem struct {
+	Date     string  `json:"date,omitempty" yaml:"date,omitempty"`
+	Name     string  `json:"name,omitempty" yaml:"name,omitempty"`
+	Duration string  `json:"duration" yaml:"duration"`
+	Seconds  float64 `json:"seconds" yaml:"seconds"`
+}
+
+func newSummaryResult(start, end time.Time, report, previousReport *stats.WeeklyReport) *summaryResult {
+
+	result := &summaryResult{
+		Start:               start.Format(dateLayout),
+		End:                 end.Format(dateLayout),
+		Days:                []durationItem{},
+		Projects:            []durationItem{},
+		DailyAverage:        formatDuration(report.DailyAverage),
+		DailyAverageSeconds: report.DailyAverage.Seconds(),
+		Median:              formatDuration(report.Median),
+		LongestStreak:       report.LongestStreak,
+		Total:               formatDuration(report.Total),
+		TotalSeconds:        report.Total.Seconds(),
+		Previous: &previousResult{
+			Start:               start.AddDate(0, 0, -7).Format(dateLayout),
+			End:                 end.AddDate(0, 0, -7).Format(dateLayout),
+			DailyAverage:        formatDuration(previousReport.DailyAverage),
+			DailyAverageSeconds: previousReport.DailyAverage.Seconds(),
+			Total:               formatDuration(previousReport.Total),
+			TotalSeconds:        previousReport.Total.Seconds(),
+		},
+	}
+
+	for _, day := range report.Days {
+		result.Days = append(result.Days, durationItem{Date: day.Date.Format(dateLayout), Duration: formatDuration(day.Total), Seconds: day.Total.Seconds()})
+	}
+
+	for _, project := range report.Projects {
+		result.Projects = append(result.Projects, durationItem{Name: project.Name, Duration: formatDuration(project.Total), Seconds: project.Total.Seconds()})
+	}
+
+	if report.HasActivity() {
+		result.MostActiveDay = report.Days[report.MostActiveDay].Date.Format(dateLayout)
+	}
+
+	return result
+
+}
+
+func formatDuration(d time.Duration) string {
+	return d.Round(time.Second).String()
+}
@@ -0,0 +1,249 @@
+package summary
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Youngtard/wakalog/calendar"
+	"github.com/Youngtard/wakalog/pkg/cmdutil"
+	"github.com/Youngtard/wakalog/stats"
+	"github.com/Youngtard/wakalog/wakalog"
+	"github.com/Youngtard/wakalog/wakatime"
+	"github.com/spf13/cobra"
+)
+
+const dateLayout = "2006-01-02"
+
+// barWidth is the width of the bar of the most active day
+const barWidth = 30
+
+type SummaryOptions struct {
+	Projects []string
+	Week     string
+	WeeksAgo int
+	NoInput  bool
+}
+
+func NewSummaryCommand(app *wakalog.Application) *cobra.Command {
+
+	opts := &SummaryOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "summary",
+		Short: "View your weekly summary",
+		Long: `View your weekly summary activity in the terminal, compared with the previous week, without writing to the Spreadsheet.
+
+By default, last working week is summarized (or the current week on weekends), across all projects.`,
+		Example: `  wakalog summary
+  wakalog summary --week 2026-W38 --projects wakalog,api
+  wakalog summary --weeks-ago 1 --output json`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+
+			interactive := !opts.NoInput && cmdutil.IsInteractive()
+
+			wakatimeAPIKey, err := cmdutil.EnsureWakaTimeAPIKey(cmd.Context(), app.WakaTimeURL, interactive, os.Stderr)
+
+			if err != nil {
+				return err
+			}
+
+			app.InitializeWakaTime(wakatimeAPIKey)
+
+			return nil
+
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			start, err := getWeekStart(opts, app.Now())
+
+			if err != nil {
+				return err
+			}
+
+			end := start.AddDate(0, 0, app.Config.WeekLength()-1)
+
+			result, err := getSummary(cmd.Context(), app, opts, start, end)
+
+			if err != nil {
+				return err
+			}
+
+			return cmdutil.PrintOutput(os.Stdout, app.Output, result, func(w io.Writer) error {
+				return printSummary(w, result)
+			})
+
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&opts.Projects, "projects", nil, "Comma separated list of projects to summarize (default all projects)")
+	cmd.Flags().StringVar(&opts.Week, "week", "", "ISO week to summarize e.g. 2026-W38")
+	cmd.Flags().IntVar(&opts.WeeksAgo, "weeks-ago", 0, "Summarize the week N weeks before the current week")
+	cmd.Flags().BoolVar(&opts.NoInput, "no-input", false, "Disable prompts and fail when a required value is missing")
+
+	cmd.MarkFlagsMutuallyExclusive("week", "weeks-ago")
+
+	return cmd
+
+}
+
+// getWeekStart returns the Monday of the week to summarize, defaulting to the last working week
+func getWeekStart(opts *SummaryOptions, now time.Time) (time.Time, error) {
+
+	switch {
+	case opts.Week != "":
+
+		start, err := calendar.ParseISOWeek(opts.Week)
+
+		if err != nil {
+			return time.Time{}, &wakalog.FlagError{Err: fmt.Errorf("invalid --week: %w", err)}
+		}
+
+		return start, nil
+
+	case opts.WeeksAgo != 0:
+
+		start, err := calendar.WeeksAgo(now, opts.WeeksAgo)
+
+		if err != nil {
+			return time.Time{}, &wakalog.FlagError{Err: fmt.Errorf("invalid --weeks-ago %d: %w", opts.WeeksAgo, err)}
+		}
+
+		return start, nil
+
+	default:
+
+		return calendar.LastWorkingWeek(now), nil
+
+	}
+
+}
+
+// getSummary reports the days from start to end, and the same days of the previous week for comparison
+func getSummary(ctx context.Context, app *wakalog.Application, opts *SummaryOptions, start, end time.Time) (*summaryResult, error) {
+
+	summariesOpts := &wakatime.SummariesOptions{Timezone: app.Timezone}
+
+	summaries, err := app.WakaTime.GetSummaries(ctx, start, end, summariesOpts)
+
+	if err != nil {
+		return nil, fmt.Errorf("error getting summaries: %w", err)
+	}
+
+	previousStart, previousEnd := start.AddDate(0, 0, -7), end.AddDate(0, 0, -7)
+
+	previousSummaries, err := app.WakaTime.GetSummaries(ctx, previousStart, previousEnd, summariesOpts)
+
+	if err != nil {
+		return nil, fmt.Errorf("error getting summaries of previous week: %w", err)
+	}
+
+	projects := opts.Projects
+
+	if len(projects) == 0 {
+		projects = append(stats.ProjectNames(summaries), stats.ProjectNames(previousSummaries)...)
+	}
+
+	report := stats.NewWeeklyReport(stats.DaysFromSummaries(summaries, start, projects))
+	previousReport := stats.NewWeeklyReport(stats.DaysFromSummaries(previousSummaries, previousStart, projects))
+
+	return newSummaryResult(start, end, report, previousReport), nil
+
+}
+
+// printSummary prints a bar per day, the time spent per project and the weekly totals compared with the previous week
+func printSummary(w io.Writer, result *summaryResult) error {
+
+	start, _ := time.Parse(dateLayout, result.Start)
+	end, _ := time.Parse(dateLayout, result.End)
+
+	fmt.Fprintf(w, "%s - %s\n\n", start.Format("Mon 2 Jan 2006"), end.Format("Mon 2 Jan 2006"))
+
+	if result.TotalSeconds == 0 {
+		fmt.Fprintln(w, "No activity found for the week.")
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	var maxSeconds float64
+
+	for _, day := range result.Days {
+		maxSeconds = max(maxSeconds, day.Seconds)
+	}
+
+	for _, day := range result.Days {
+
+		date, _ := time.Parse(dateLayout, day.Date)
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", date.Format("Mon 2 Jan"), bar(day.Seconds, maxSeconds), day.Duration)
+
+	}
+
+	if len(result.Projects) > 0 {
+
+		fmt.Fprintln(tw)
+
+		for _, project := range result.Projects {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", project.Name, bar(project.Seconds, result.TotalSeconds), project.Duration)
+		}
+
+	}
+
+	fmt.Fprintln(tw)
+	fmt.Fprintf(tw, "Daily Average\t%s\t%s\n", result.DailyAverage, formatChange(result.DailyAverageSeconds, result.Previous.DailyAverageSeconds))
+	fmt.Fprintf(tw, "Median\t%s\t\n", result.Median)
+	fmt.Fprintf(tw, "Most Active Day\t%s\t\n", formatDate(result.MostActiveDay))
+	fmt.Fprintf(tw, "Longest Streak\t%d days\t\n", result.LongestStreak)
+	fmt.Fprintf(tw, "Total\t%s\t%s\n", result.Total, formatChange(result.TotalSeconds, result.Previous.TotalSeconds))
+
+	return tw.Flush()
+
+}
+
+// bar returns a bar of seconds relative to maxSeconds, the width of a full bar
+func bar(seconds, maxSeconds float64) string {
+
+	if maxSeconds <= 0 {
+		return ""
+	}
+
+	return strings.Repeat("█", int(seconds/maxSeconds*barWidth+0.5))
+
+}
+
+// formatChange formats the change from previous to current e.g. "+1h30m0s (+25%) vs previous week"
+func formatChange(current, previous float64) string {
+
+	diff := time.Duration((current - previous) * float64(time.Second)).Round(time.Second)
+
+	sign := "+"
+
+	if diff < 0 {
+		sign = "-"
+		diff = -diff
+	}
+
+	if previous == 0 {
+		return fmt.Sprintf("%s%s vs previous week", sign, diff)
+	}
+
+	return fmt.Sprintf("%s%s (%+.0f%%) vs previous week", sign, diff, (current-previous)/previous*100)
+
+}
+
+func formatDate(date string) string {
+
+	t, err := time.Parse(dateLayout, date)
+
+	if err != nil {
+		return "-"
+	}
+
+	return t.Format("Mon 2 Jan")
+
+}
@@ -0,0 +1,230 @@
+package httpclient
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+	"sync"
+)
+
+// Cache stores responses of conditional requests. Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the value stored for key, and whether it was found
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte)
+	Delete(key string)
+}
+
+// MemoryCache is a Cache holding values in memory
+type MemoryCache struct {
+	mu     sync.RWMutex
+	values map[string][]byte
+}
+
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{values: map[string][]byte{}}
+}
+
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	value, ok := c.values[key]
+
+	return value, ok
+
+}
+
+func (c *MemoryCache) Set(key string, value []byte) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.values[key] = value
+
+}
+
+func (c *MemoryCache) Delete(key string) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.values, key)
+
+}
+
+// FromCacheHeader is set on responses served from the cache after a 304 Not Modified
+const FromCacheHeader = "X-From-Cache"
+
+// CacheTransport stores GET responses carrying an ETag or Last-Modified validator, revalidating them with
+// If-None-Match and If-Modified-Since on subsequent requests. The stored response is served when the server responds 304 Not Modified.
+type CacheTransport struct {
+	// Transport makes the requests, http.DefaultTransport if nil
+	Transport http.RoundTripper
+	Cache     Cache
+}
+
+// WithCache returns a copy of the client revalidating responses stored in cache.
+// Set auth after the cache so credentials are part of cache keys.
+func (c *Client) WithCache(cache Cache) *Client {
+
+	c2 := c.copy()
+	c2.client.Transport = &CacheTransport{Transport: c2.client.Transport, Cache: cache}
+
+	return c2
+
+}
+
+func (t *CacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	transport := t.Transport
+
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	if req.Method != http.MethodGet || req.Header.Get("Range") != "" {
+		return transport.RoundTrip(req)
+	}
+
+	key := cacheKey(req)
+
+	cached := t.load(key, req)
+
+	if cached != nil {
+
+		req = req.Clone(req.Context())
+
+		/// Validators set by the caller take precedence
+		if etag := cached.Header.Get("ETag"); etag != "" && req.Header.Get("If-None-Match") == "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+
+		if lastModified := cached.Header.Get("Last-Modified"); lastModified != "" && req.Header.Get("If-Modified-Since") == "" {
+			req.Header.Set("If-Modified-Since", lastModified)
+		}
+
+	}
+
+	resp, err := transport.RoundTrip(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		/// A 304 may carry updated validators
+		for _, header := range []string{"ETag", "Last-Modified", "Cache-Control", "Date", "Expires"} {
+			if value := resp.Header.Get(header); value != "" {
+				cached.Header.Set(header, value)
+			}
+		}
+
+		t.store(key, cached)
+
+		cached.Header.Set(FromCacheHeader, "1")
+
+		return cached, nil
+
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+
+	if !isCacheable(resp) {
+		/// The stored response no longer matches what the server returns
+		if cached != nil {
+			t.Cache.Delete(key)
+		}
+		return resp, nil
+	}
+
+	/// Caching is best effort, resp is returned uncached if storing fails
+	t.store(key, resp)
+
+	return resp, nil
+
+}
+
+// load returns the response stored for key, or nil if missing or unreadable
+func (t *CacheTransport) load(key string, req *http.Request) *http.Response {
+
+	value, ok := t.Cache.Get(key)
+
+	if !ok {
+		return nil
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(value)), req)
+
+	if err != nil {
+		t.Cache.Delete(key)
+		return nil
+	}
+
+	return resp
+
+}
+
+// store saves resp under key, leaving resp readable. A body failing to read is left failing with the same error.
+func (t *CacheTransport) store(key string, resp *http.Response) error {
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if err != nil {
+		resp.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), errReader{err}))
+		return err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	value, err := httputil.DumpResponse(resp, true)
+
+	if err != nil {
+		return err
+	}
+
+	/// DumpResponse consumes the body
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	t.Cache.Set(key, value)
+
+	return nil
+
+}
+
+func isCacheable(resp *http.Response) bool {
+
+	if strings.Contains(strings.ToLower(resp.Header.Get("Cache-Control")), "no-store") {
+		return false
+	}
+
+	return resp.Header.Get("ETag") != "" || resp.Header.Get("Last-Modified") != ""
+
+}
+
+// cacheKey identifies a request by its URL and credentials, so responses are never served to another user
+func cacheKey(req *http.Request) string {
+
+	key := req.URL.String()
+
+	if auth := req.Header.Get("Authorization"); auth != "" {
+		sum := sha256.Sum256([]byte(auth))
+		key += " " + hex.EncodeToString(sum[:8])
+	}
+
+	return key
+
+}
@@ -0,0 +1,162 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCacheTransport(t *testing.T) {
+
+	tests := []struct {
+		name  string
+		etag  string
+		cache string
+		// wantRevalidated is whether the second request carries If-None-Match
+		wantRevalidated bool
+		wantFromCache   bool
+	}{
+		{name: "etag round-trip", etag: `"v1"`, wantRevalidated: true, wantFromCache: true},
+		{name: "no-store", etag: `"v1"`, cache: "no-store", wantRevalidated: false, wantFromCache: false},
+		{name: "no validators", wantRevalidated: false, wantFromCache: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			var ifNoneMatch []string
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+				ifNoneMatch = append(ifNoneMatch, r.Header.Get("If-None-Match"))
+
+				if tt.etag != "" {
+					w.Header().Set("ETag", tt.etag)
+				}
+
+				if tt.cache != "" {
+					w.Header().Set("Cache-Control", tt.cache)
+				}
+
+				if tt.etag != "" && r.Header.Get("If-None-Match") == tt.etag {
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+
+				io.WriteString(w, `{"data":"fresh"}`)
+
+			}))
+			defer server.Close()
+
+			client := NewClient(nil).WithCache(NewMemoryCache())
+
+			for i := 0; i < 2; i++ {
+
+				var v struct{ Data string }
+
+				resp, err := client.Get(context.Background(), server.URL, nil, &v)
+
+				if err != nil {
+					t.Fatalf("request %d: %v", i, err)
+				}
+
+				if v.Data != "fresh" {
+					t.Errorf("request %d: data = %q, want %q", i, v.Data, "fresh")
+				}
+
+				if fromCache := resp.Header.Get(FromCacheHeader) != ""; i == 1 && fromCache != tt.wantFromCache {
+					t.Errorf("served from cache = %t, want %t", fromCache, tt.wantFromCache)
+				}
+
+			}
+
+			if ifNoneMatch[0] != "" {
+				t.Errorf("first request sent If-None-Match %q", ifNoneMatch[0])
+			}
+
+			if revalidated := ifNoneMatch[1] != ""; revalidated != tt.wantRevalidated {
+				t.Errorf("second request revalidated = %t, want %t", revalidated, tt.wantRevalidated)
+			}
+
+		})
+	}
+
+}
+
+func TestCacheTransportDeletesWhenValidatorsDropped(t *testing.T) {
+
+	etag := `"v1"`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		if etag != "" {
+			w.Header().Set("ETag", etag)
+		}
+
+		io.WriteString(w, "{}")
+
+	}))
+	defer server.Close()
+
+	cache := NewMemoryCache()
+	client := NewClient(nil).WithCache(cache)
+
+	if _, err := client.Get(context.Background(), server.URL, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(cache.values) != 1 {
+		t.Fatalf("cached %d responses, want 1", len(cache.values))
+	}
+
+	etag = ""
+
+	if _, err := client.Get(context.Background(), server.URL, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(cache.values) != 0 {
+		t.Errorf("cached %d responses after a 200 without validators, want 0", len(cache.values))
+	}
+
+}
+
+func TestCacheTransportStoreFailure(t *testing.T) {
+
+	readErr := errors.New("connection reset")
+
+	transport := &CacheTransport{
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Etag": {`"v1"`}},
+				Body:       io.NopCloser(io.MultiReader(strings.NewReader("partial"), errReader{readErr})),
+				Request:    req,
+			}, nil
+		}),
+		Cache: NewMemoryCache(),
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+
+	resp, err := transport.RoundTrip(req)
+
+	if err != nil {
+		t.Fatalf("RoundTrip() error = %v, want the response uncached", err)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+
+	if string(body) != "partial" || !errors.Is(err, readErr) {
+		t.Errorf("body = %q, %v, want %q, %v", body, err, "partial", readErr)
+	}
+
+	if _, ok := transport.Cache.Get(cacheKey(req)); ok {
+		t.Error("response with a failed body was cached")
+	}
+
+}
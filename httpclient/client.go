@@ -1,15 +1,20 @@
 package httpclient
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 )
 
 type Client struct {
-	client *http.Client
+	client      *http.Client
+	retryPolicy *RetryPolicy
+	logger      *slog.Logger
 }
 
 type roundTripperFunc func(*http.Request) (*http.Response, error)
@@ -40,7 +45,9 @@ func (c *Client) WithAuthToken(token string) *Client {
 	c2.client.Transport = roundTripperFunc(
 		func(req *http.Request) (*http.Response, error) {
 			req = req.Clone(req.Context())
-			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+			if req.Header.Get("Authorization") == "" {
+				req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+			}
 			return transport.RoundTrip(req)
 		},
 	)
@@ -57,7 +64,9 @@ func (c *Client) WithBasicAuth(value string) *Client {
 	c2.client.Transport = roundTripperFunc(
 		func(req *http.Request) (*http.Response, error) {
 			req = req.Clone(req.Context())
-			req.Header.Set("Authorization", fmt.Sprintf("Basic %s", value))
+			if req.Header.Get("Authorization") == "" {
+				req.Header.Set("Authorization", fmt.Sprintf("Basic %s", value))
+			}
 			return transport.RoundTrip(req)
 		},
 	)
@@ -67,7 +76,9 @@ func (c *Client) WithBasicAuth(value string) *Client {
 func (c *Client) copy() *Client {
 
 	clone := Client{
-		client: &http.Client{},
+		client:      &http.Client{},
+		retryPolicy: c.retryPolicy,
+		logger:      c.logger,
 	}
 
 	if c.client != nil {
@@ -91,22 +102,67 @@ func (se *ServerError) Error() string {
 
 }
 
-func (c *Client) Get(ctx context.Context, urlPath string, params url.Values, v interface{}) (*http.Response, error) {
+// RequestOption modifies a request before it is sent e.g. to override headers
+type RequestOption func(req *http.Request)
+
+// WithHeader sets a request header, overriding headers set by the client (including Authorization)
+func WithHeader(key, value string) RequestOption {
+	return func(req *http.Request) {
+		req.Header.Set(key, value)
+	}
+}
+
+func (c *Client) Get(ctx context.Context, urlPath string, params url.Values, v interface{}, opts ...RequestOption) (*http.Response, error) {
 	url, err := url.Parse(urlPath)
 
 	if err != nil {
 		return nil, fmt.Errorf("error parsing url path: %w", err)
 	}
-	url.Query()
 
-	url.RawQuery = params.Encode()
+	query := url.Query()
+
+	for key, values := range params {
+		query[key] = values
+	}
+
+	url.RawQuery = query.Encode()
+
+	return c.do(ctx, http.MethodGet, url.String(), nil, v, opts...)
+
+}
+
+// Post sends body encoded as JSON, decoding the response into v
+func (c *Client) Post(ctx context.Context, urlPath string, body interface{}, v interface{}, opts ...RequestOption) (*http.Response, error) {
+	return c.do(ctx, http.MethodPost, urlPath, body, v, opts...)
+}
+
+// Put sends body encoded as JSON, decoding the response into v
+func (c *Client) Put(ctx context.Context, urlPath string, body interface{}, v interface{}, opts ...RequestOption) (*http.Response, error) {
+	return c.do(ctx, http.MethodPut, urlPath, body, v, opts...)
+}
+
+// Patch sends body encoded as JSON, decoding the response into v
+func (c *Client) Patch(ctx context.Context, urlPath string, body interface{}, v interface{}, opts ...RequestOption) (*http.Response, error) {
+	return c.do(ctx, http.MethodPatch, urlPath, body, v, opts...)
+}
+
+// Delete decodes the response into v
+func (c *Client) Delete(ctx context.Context, urlPath string, v interface{}, opts ...RequestOption) (*http.Response, error) {
+	return c.do(ctx, http.MethodDelete, urlPath, nil, v, opts...)
+}
+
+func (c *Client) do(ctx context.Context, method string, urlPath string, body interface{}, v interface{}, opts ...RequestOption) (*http.Response, error) {
 
-	req, err := c.createRequest(http.MethodGet, url.String(), nil)
+	req, err := c.createRequest(method, urlPath, body)
 
 	if err != nil {
 		return nil, err
 	}
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.processRequest(ctx, req)
 
 	if err != nil {
@@ -125,13 +181,47 @@ func (c *Client) Get(ctx context.Context, urlPath string, params url.Values, v i
 
 }
 
-func (c *Client) createRequest(method string, url string, body io.Reader) (*http.Request, error) {
-	req, err := http.NewRequest(method, url, body)
+// createRequest creates a request with body encoded as JSON. An io.Reader body is sent as is,
+// buffered so it can be resent on retry.
+func (c *Client) createRequest(method string, url string, body interface{}) (*http.Request, error) {
+
+	var bodyReader io.Reader
+	var contentType string
+
+	switch body := body.(type) {
+	case nil:
+	case io.Reader:
+		/// http.NewRequest only sets GetBody for in-memory readers, which retries rely on to resend the body
+		buf, err := io.ReadAll(body)
+
+		if err != nil {
+			return nil, fmt.Errorf("error reading request body: %w", err)
+		}
+
+		bodyReader = bytes.NewReader(buf)
+	default:
+		buf, err := json.Marshal(body)
+
+		if err != nil {
+			return nil, fmt.Errorf("error encoding request body: %w", err)
+		}
+
+		bodyReader = bytes.NewReader(buf)
+		contentType = "application/json"
+	}
+
+	req, err := http.NewRequest(method, url, bodyReader)
 
 	if err != nil {
 		return nil, fmt.Errorf("error creating request: %w", err)
 	}
 
+	req.Header.Set("Accept", "application/json")
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
 	return req, nil
 
 }
@@ -140,23 +230,93 @@ func (c *Client) processRequest(ctx context.Context, req *http.Request) (*http.R
 
 	req = req.WithContext(ctx)
 
-	resp, err := c.client.Do(req)
+	var maxRetries int
+
+	/// A body that can't be rewound would be resent empty
+	rewindable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+
+	if c.retryPolicy != nil && isIdempotent(req.Method) && rewindable {
+		maxRetries = c.retryPolicy.MaxRetries
+	}
+
+	for attempt := 0; ; attempt++ {
+
+		if attempt > 0 && req.GetBody != nil {
+
+			body, err := req.GetBody()
+
+			if err != nil {
+				return nil, fmt.Errorf("error rewinding request body: %w", err)
+			}
+
+			req.Body = body
+
+		}
+
+		resp, err := c.client.Do(req)
+
+		if err != nil {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+			}
+
+			if attempt < maxRetries && isTransient(err) {
+
+				wait, _ := c.retryPolicy.backoff(attempt+1, nil)
+
+				c.debug("retrying request", "method", req.Method, "url", req.URL.String(), "retry", attempt+1, "wait", wait, "error", err)
+
+				if err := sleep(ctx, wait); err != nil {
+					return nil, err
+				}
+
+				continue
+
+			}
 
-	if err != nil {
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
 			return nil, fmt.Errorf("error making request: %w", err)
+
 		}
 
-	}
+		success := resp.StatusCode >= 200 && resp.StatusCode < 400
+
+		if success {
+			return resp, nil
+		}
+
+		if attempt < maxRetries && retryableStatusCodes[resp.StatusCode] {
+
+			if wait, ok := c.retryPolicy.backoff(attempt+1, resp); ok {
+
+				c.debug("retrying request", "method", req.Method, "url", req.URL.String(), "retry", attempt+1, "wait", wait, "status", resp.StatusCode)
+
+				// Drain body so the connection can be reused
+				io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+
+				if err := sleep(ctx, wait); err != nil {
+					return nil, err
+				}
+
+				continue
 
-	success := resp.StatusCode >= 200 && resp.StatusCode < 400
+			}
+
+		}
 
-	if !success {
 		return nil, &ServerError{Body: resp.Body, StatusCode: resp.StatusCode}
+
+	}
+
+}
+
+// debug logs at debug level when the client has a logger
+func (c *Client) debug(msg string, args ...any) {
+
+	if c.logger != nil {
+		c.logger.Debug(msg, args...)
 	}
 
-	return resp, nil
 }
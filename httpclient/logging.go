@@ -0,0 +1,181 @@
+package httpclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// redacted replaces the value of headers holding credentials in traces
+const redacted = "REDACTED"
+
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// LoggingTransport logs the method, URL, status and latency of requests at debug level.
+// When Trace is set, full requests and responses are also written to it as JSON lines, with credentials redacted.
+type LoggingTransport struct {
+	// Transport makes the requests, http.DefaultTransport if nil
+	Transport http.RoundTripper
+	Logger    *slog.Logger
+	Trace     io.Writer
+
+	mu sync.Mutex
+}
+
+// WithLogger returns a copy of the client logging requests and retries to logger, and tracing requests to trace when not nil.
+// Set auth after the logger so the Authorization header is redacted from traces rather than missing.
+func (c *Client) WithLogger(logger *slog.Logger, trace io.Writer) *Client {
+
+	c2 := c.copy()
+	c2.logger = logger
+	c2.client.Transport = &LoggingTransport{Transport: c2.client.Transport, Logger: logger, Trace: trace}
+
+	return c2
+
+}
+
+// traceEntry is a line of a trace
+type traceEntry struct {
+	Time            time.Time   `json:"time"`
+	Method          string      `json:"method"`
+	URL             string      `json:"url"`
+	RequestHeaders  http.Header `json:"request_headers"`
+	RequestBody     string      `json:"request_body,omitempty"`
+	Status          int         `json:"status,omitempty"`
+	ResponseHeaders http.Header `json:"response_headers,omitempty"`
+	ResponseBody    string      `json:"response_body,omitempty"`
+	DurationMS      int64       `json:"duration_ms"`
+	Error           string      `json:"error,omitempty"`
+}
+
+func (t *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	transport := t.Transport
+
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	var entry *traceEntry
+
+	if t.Trace != nil {
+
+		entry = &traceEntry{
+			Time:           time.Now(),
+			Method:         req.Method,
+			URL:            req.URL.String(),
+			RequestHeaders: redactHeaders(req.Header),
+		}
+
+		if req.GetBody != nil {
+			if body, err := req.GetBody(); err == nil {
+				data, _ := io.ReadAll(body)
+				entry.RequestBody = string(data)
+			}
+		}
+
+	}
+
+	start := time.Now()
+
+	resp, err := transport.RoundTrip(req)
+
+	latency := time.Since(start)
+
+	if err != nil {
+		t.logger().Debug("http request failed", "method", req.Method, "url", req.URL.String(), "latency", latency, "error", err)
+	} else {
+		t.logger().Debug("http request", "method", req.Method, "url", req.URL.String(), "status", resp.StatusCode, "latency", latency)
+	}
+
+	if entry == nil {
+		return resp, err
+	}
+
+	entry.DurationMS = latency.Milliseconds()
+
+	if err != nil {
+
+		entry.Error = err.Error()
+
+	} else {
+
+		entry.Status = resp.StatusCode
+		entry.ResponseHeaders = redactHeaders(resp.Header)
+
+		body, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		/// Hand the caller the body read so far, along with any read error
+		resp.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), errReader{readErr}))
+		entry.ResponseBody = string(body)
+
+	}
+
+	t.writeTrace(entry)
+
+	return resp, err
+
+}
+
+func (t *LoggingTransport) logger() *slog.Logger {
+
+	if t.Logger == nil {
+		return slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+
+	return t.Logger
+
+}
+
+// writeTrace writes entry as a single line so concurrent requests don't interleave
+func (t *LoggingTransport) writeTrace(entry *traceEntry) {
+
+	line, err := json.Marshal(entry)
+
+	if err != nil {
+		t.logger().Debug("error encoding trace", "error", err)
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, err := t.Trace.Write(append(line, '\n')); err != nil {
+		t.logger().Debug("error writing trace", "error", err)
+	}
+
+}
+
+func redactHeaders(header http.Header) http.Header {
+
+	clone := header.Clone()
+
+	for _, name := range sensitiveHeaders {
+		if clone.Get(name) != "" {
+			clone.Set(name, redacted)
+		}
+	}
+
+	return clone
+
+}
+
+// errReader returns err once the body is consumed, or io.EOF if err is nil
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read(p []byte) (int, error) {
+
+	if r.err == nil {
+		return 0, io.EOF
+	}
+
+	return 0, r.err
+
+}
@@ -0,0 +1,135 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy configures retrying of idempotent requests failing with a rate limit, a server error or a transient network error
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt
+	MaxRetries int
+	// MinBackoff is the backoff before the first retry, doubled on each subsequent retry
+	MinBackoff time.Duration
+	// MaxBackoff caps the backoff between retries. A Retry-After longer than MaxBackoff is not waited for.
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy retries up to 3 times, backing off from 500ms up to 30s
+func DefaultRetryPolicy() RetryPolicy {
+
+	return RetryPolicy{
+		MaxRetries: 3,
+		MinBackoff: 500 * time.Millisecond,
+		MaxBackoff: 30 * time.Second,
+	}
+
+}
+
+// WithRetryPolicy returns a copy of the client retrying requests according to policy
+func (c *Client) WithRetryPolicy(policy RetryPolicy) *Client {
+
+	c2 := c.copy()
+	c2.retryPolicy = &policy
+	return c2
+
+}
+
+var retryableStatusCodes = map[int]bool{
+	http.StatusTooManyRequests:     true,
+	http.StatusInternalServerError: true,
+	http.StatusBadGateway:          true,
+	http.StatusServiceUnavailable:  true,
+	http.StatusGatewayTimeout:      true,
+}
+
+func isIdempotent(method string) bool {
+
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+
+}
+
+// isTransient reports whether a network error is likely to succeed on retry
+func isTransient(err error) bool {
+
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	var netError net.Error
+
+	return errors.As(err, &netError) && netError.Timeout()
+
+}
+
+// backoff returns the wait before retry attempt (starting at 1) using exponential backoff with full jitter,
+// or the Retry-After of resp if set. false is returned when Retry-After exceeds MaxBackoff.
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) (time.Duration, bool) {
+
+	if resp != nil {
+
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return retryAfter, retryAfter <= p.MaxBackoff
+		}
+
+	}
+
+	ceiling := p.MinBackoff << (attempt - 1)
+
+	if ceiling <= 0 || ceiling > p.MaxBackoff {
+		ceiling = p.MaxBackoff
+	}
+
+	if ceiling <= 0 {
+		return 0, true
+	}
+
+	return time.Duration(rand.Int63n(int64(ceiling) + 1)), true
+
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+
+}
+
+// sleep waits for d, returning early with the context's error if it is done
+func sleep(ctx context.Context, d time.Duration) error {
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+
+}
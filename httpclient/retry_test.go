@@ -0,0 +1,188 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testRetryPolicy retries quickly so tests don't wait on backoff
+var testRetryPolicy = RetryPolicy{MaxRetries: 3, MinBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond}
+
+// recorder responds with statuses in order, repeating the last one, and records request bodies
+type recorder struct {
+	mu         sync.Mutex
+	statuses   []int
+	retryAfter string
+	bodies     []string
+}
+
+func (rec *recorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	body, _ := io.ReadAll(r.Body)
+	rec.bodies = append(rec.bodies, string(body))
+
+	status := rec.statuses[min(len(rec.bodies), len(rec.statuses))-1]
+
+	if rec.retryAfter != "" {
+		w.Header().Set("Retry-After", rec.retryAfter)
+	}
+
+	w.WriteHeader(status)
+
+}
+
+func (rec *recorder) requests() int {
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	return len(rec.bodies)
+
+}
+
+func TestRetry(t *testing.T) {
+
+	tests := []struct {
+		name         string
+		method       string
+		body         interface{}
+		statuses     []int
+		retryAfter   string
+		wantRequests int
+		wantStatus   int
+	}{
+		{name: "429 with Retry-After is retried", method: http.MethodGet, statuses: []int{429, 200}, retryAfter: "0", wantRequests: 2, wantStatus: 200},
+		{name: "Retry-After above MaxBackoff is not retried", method: http.MethodGet, statuses: []int{429, 200}, retryAfter: "120", wantRequests: 1, wantStatus: 429},
+		{name: "5xx is retried up to MaxRetries", method: http.MethodGet, statuses: []int{503}, wantRequests: 4, wantStatus: 503},
+		{name: "5xx then success", method: http.MethodGet, statuses: []int{500, 502, 200}, wantRequests: 3, wantStatus: 200},
+		{name: "4xx is not retried", method: http.MethodGet, statuses: []int{404}, wantRequests: 1, wantStatus: 404},
+		{name: "POST is never retried", method: http.MethodPost, body: map[string]string{"a": "b"}, statuses: []int{503, 200}, wantRequests: 1, wantStatus: 503},
+		{name: "DELETE is retried", method: http.MethodDelete, statuses: []int{503, 204}, wantRequests: 2, wantStatus: 204},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			rec := &recorder{statuses: tt.statuses, retryAfter: tt.retryAfter}
+			server := httptest.NewServer(rec)
+			defer server.Close()
+
+			client := NewClient(nil).WithRetryPolicy(testRetryPolicy)
+
+			var resp *http.Response
+			var err error
+
+			switch tt.method {
+			case http.MethodGet:
+				resp, err = client.Get(context.Background(), server.URL, nil, nil)
+			case http.MethodPost:
+				resp, err = client.Post(context.Background(), server.URL, tt.body, nil)
+			case http.MethodDelete:
+				resp, err = client.Delete(context.Background(), server.URL, nil)
+			}
+
+			status := 0
+
+			var serverError *ServerError
+
+			switch {
+			case err == nil:
+				status = resp.StatusCode
+			case errors.As(err, &serverError):
+				status = serverError.StatusCode
+			default:
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if status != tt.wantStatus {
+				t.Errorf("status = %d, want %d", status, tt.wantStatus)
+			}
+
+			if got := rec.requests(); got != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", got, tt.wantRequests)
+			}
+
+		})
+	}
+
+}
+
+func TestRetryRewindsPutBody(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		body     interface{}
+		wantBody string
+	}{
+		{name: "json", body: map[string]string{"name": "wakalog"}, wantBody: `{"name":"wakalog"}`},
+		{name: "reader", body: io.NopCloser(strings.NewReader("raw body")), wantBody: "raw body"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			rec := &recorder{statuses: []int{503, 503, 200}}
+			server := httptest.NewServer(rec)
+			defer server.Close()
+
+			client := NewClient(nil).WithRetryPolicy(testRetryPolicy)
+
+			_, err := client.Put(context.Background(), server.URL, tt.body, nil)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(rec.bodies) != 3 {
+				t.Fatalf("requests = %d, want 3", len(rec.bodies))
+			}
+
+			for i, body := range rec.bodies {
+				if strings.TrimSpace(body) != tt.wantBody {
+					t.Errorf("attempt %d body = %q, want %q", i+1, body, tt.wantBody)
+				}
+			}
+
+		})
+	}
+
+}
+
+func TestRetryCancelledDuringBackoff(t *testing.T) {
+
+	rec := &recorder{statuses: []int{503}, retryAfter: "30"}
+	server := httptest.NewServer(rec)
+	defer server.Close()
+
+	client := NewClient(nil).WithRetryPolicy(RetryPolicy{MaxRetries: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Minute})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+
+	_, err := client.Get(ctx, server.URL, nil, nil)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("returned after %s, want the backoff interrupted", elapsed)
+	}
+
+	if got := rec.requests(); got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+
+}
@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 
 	"github.com/Youngtard/wakalog/cmd/wakalog/command"
 	wakasheets "github.com/Youngtard/wakalog/sheets"
@@ -25,21 +27,40 @@ func startCli(ctx context.Context, app *wakalog.Application) (*cobra.Command, er
 
 func main() {
 	wakasheets.GoogleCredentials = googleCredentials
-	ctx := context.Background()
+	/// Cancel the context on Ctrl-C so prompts and the local OAuth server shut down gracefully
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		<-ctx.Done()
+		stop() // a second Ctrl-C terminates immediately
+	}()
 
 	app := wakalog.NewApplication(ctx)
 
-	if cmd, err := startCli(ctx, app); err != nil {
+	cmd, err := startCli(ctx, app)
+
+	/// Checked before stop, which also cancels the context
+	interrupted := ctx.Err() != nil
+
+	stop()
+	app.Close()
+
+	if err != nil {
 		errorLog := log.New(os.Stderr, "", 0)
 
-		errorCode := 1
+		exitCode := wakalog.ExitCode(err)
+
+		/// Prompts report an interrupt as a timeout, so rely on the context instead
+		if interrupted {
+			exitCode = wakalog.ExitCancelled
+		}
 
 		var flagError *wakalog.FlagError
-		var authError *wakalog.AuthError
 		var wakatimeError *wakatime.WakaTimeError
+		var sheetsError *wakasheets.SheetsError
 
-		if errors.As(err, &authError) {
-			errorLog.Println(err)
+		if exitCode == wakalog.ExitCancelled && !errors.Is(err, wakalog.ErrCancelled) {
+			errorLog.Println("Cancelled.")
 		} else if errors.As(err, &flagError) || strings.HasPrefix(err.Error(), "unknown command ") {
 
 			errorLog.Println(err)
@@ -49,23 +70,30 @@ func main() {
 			}
 
 			errorLog.Println(cmd.UsageString())
-			os.Exit(1)
+			exitCode = wakalog.ExitUsage
 
 		} else if errors.As(err, &wakatimeError) {
-			errorCode = wakatimeError.StatusCode
-			errorLog.Printf("WakaTime Error: %s (%d)\n", wakatimeError, errorCode)
+			errorLog.Printf("WakaTime Error: %s (%d)\n", wakatimeError, wakatimeError.StatusCode)
 
-			if errorCode == 401 {
-				fmt.Println("Use <wakalog auth> to reauthenticate your WakaTime account")
+			if exitCode == wakalog.ExitAuthRequired {
+				errorLog.Println("Use <wakalog auth> to reauthenticate your WakaTime account")
 			}
 
-			os.Exit(errorCode)
+		} else if errors.As(err, &sheetsError) {
+			errorLog.Printf("Google Sheets Error: %s\n", err)
+
+			if guidance := sheetsError.Guidance(); guidance != "" {
+				errorLog.Println(guidance)
+			}
 
+		} else if exitCode != wakalog.ExitError {
+			errorLog.Println(err)
 		} else {
-			errorLog.Printf("An error occurred: %s (%d)\n", err, errorCode)
-			os.Exit(errorCode)
+			errorLog.Printf("An error occurred: %s\n", err)
 		}
 
+		os.Exit(exitCode)
+
 	}
 
 }
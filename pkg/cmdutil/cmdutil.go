@@ -3,9 +3,16 @@ package cmdutil
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
+
+	"github.com/Youngtard/wakalog/wakalog"
+	"github.com/Youngtard/wakalog/wakatime"
+	"github.com/mattn/go-isatty"
+	"github.com/zalando/go-keyring"
 )
 
 // https://github.com/docker/cli/blob/master/cli/command/utils.go
@@ -34,8 +41,77 @@ func PromptForConfirmation(ctx context.Context, message string) (bool, error) {
 	select {
 	case <-ctx.Done():
 		_, _ = fmt.Fprintln(os.Stdout, "")
-		return false, fmt.Errorf("confirmation prompt terminated")
+		return false, fmt.Errorf("confirmation prompt terminated: %w", ctx.Err())
 	case r := <-result:
 		return r, nil
 	}
 }
+
+// IsInteractive reports whether both stdin and stdout are attached to a terminal, i.e. prompts can be shown
+func IsInteractive() bool {
+	return isTerminal(os.Stdin) && isTerminal(os.Stdout)
+}
+
+func isTerminal(f *os.File) bool {
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+// GetWakaTimeAPIKey returns the WakaTime API key from the WAKATIME_API_KEY environment variable, falling back to storage (Keyring) for baseURL
+func GetWakaTimeAPIKey(baseURL string) (string, error) {
+
+	if apiKey := strings.TrimSpace(os.Getenv(wakatime.APIKeyEnv)); apiKey != "" {
+		return apiKey, nil
+	}
+
+	var apiKey string
+
+	if err := wakatime.GetAPIKey(baseURL, &apiKey); err != nil {
+
+		if errors.Is(err, keyring.ErrNotFound) {
+			return "", wakalog.ErrWakaTimeAPIKeyNotFound
+		}
+
+		return "", wakalog.ErrGeneric
+
+	} else {
+
+		if len(strings.TrimSpace(apiKey)) == 0 {
+
+			return "", wakalog.ErrWakaTimeAPIKeyNotFound
+
+		}
+	}
+
+	return apiKey, nil
+
+}
+
+// EnsureWakaTimeAPIKey returns the WakaTime API key for baseURL, prompting for it when missing and interactive.
+// A prompted key is stored, and the user it belongs to printed to out.
+func EnsureWakaTimeAPIKey(ctx context.Context, baseURL string, interactive bool, out io.Writer) (string, error) {
+
+	apiKey, err := GetWakaTimeAPIKey(baseURL)
+
+	if err == nil {
+		return apiKey, nil
+	}
+
+	if !errors.Is(err, wakalog.ErrWakaTimeAPIKeyNotFound) {
+		return "", fmt.Errorf("error checking for wakatime api key: %w", err)
+	}
+
+	if !interactive {
+		return "", &wakalog.AuthError{Err: fmt.Errorf("%w: set the %s environment variable or use <wakalog auth>", err, wakatime.APIKeyEnv)}
+	}
+
+	apiKey, user, err := wakatime.Authorize(ctx, baseURL)
+
+	if err != nil {
+		return "", &wakalog.AuthError{Err: fmt.Errorf("error authenticating with WakaTime: %w", err)}
+	}
+
+	fmt.Fprintf(out, "Logged in to WakaTime as %s.\n", user)
+
+	return apiKey, nil
+
+}
@@ -0,0 +1,77 @@
+package cmdutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats of command results
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputYAML  = "yaml"
+)
+
+// OutputFormats returns the supported output formats
+func OutputFormats() []string {
+	return []string{OutputTable, OutputJSON, OutputYAML}
+}
+
+// ValidateOutputFormat returns an error if format is not a supported output format
+func ValidateOutputFormat(format string) error {
+
+	if !slices.Contains(OutputFormats(), format) {
+		return fmt.Errorf("unknown output format %q: expected one of %s", format, strings.Join(OutputFormats(), ", "))
+	}
+
+	return nil
+
+}
+
+// IsStructuredOutput reports whether format is meant for machines i.e. JSON or YAML
+func IsStructuredOutput(format string) bool {
+	return format == OutputJSON || format == OutputYAML
+}
+
+// PrintOutput writes v to w as JSON or YAML, or with printTable for table output
+func PrintOutput(w io.Writer, format string, v interface{}, printTable func(w io.Writer) error) error {
+
+	switch format {
+	case OutputJSON:
+
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		err := encoder.Encode(v)
+
+		if err != nil {
+			return fmt.Errorf("error encoding json output: %w", err)
+		}
+
+		return nil
+
+	case OutputYAML:
+
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+
+		err := encoder.Encode(v)
+
+		if err != nil {
+			return fmt.Errorf("error encoding yaml output: %w", err)
+		}
+
+		return encoder.Close()
+
+	default:
+
+		return printTable(w)
+
+	}
+
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"embed"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -20,12 +21,27 @@ import (
 
 var GoogleCredentials embed.FS
 
+// ErrAuthorizationRequired is returned by GetClient when authorization is needed but prompts are disabled
+var ErrAuthorizationRequired = errors.New("google sheets authorization required")
+
+// DefaultAuthorizationTimeout bounds the wait for authorization in the browser, after which the local server is shut down
+const DefaultAuthorizationTimeout = 5 * time.Minute
+
+const spreadsheetsScope = "https://www.googleapis.com/auth/spreadsheets"
+
+// emailScope grants the email of the authorized account, to tell which account a sheet must be shared with
+const emailScope = "https://www.googleapis.com/auth/userinfo.email"
+
 var scopes = []string{
 	"https://www.googleapis.com/auth/spreadsheets.readonly",
-	"https://www.googleapis.com/auth/spreadsheets",
+	spreadsheetsScope,
+	emailScope,
 }
 
-func GetClient(ctx context.Context) (*http.Client, error) {
+// GetClient returns an HTTP client authorized with the stored token, beginning authorization in the browser if required.
+// When interactive is false, ErrAuthorizationRequired is returned instead of opening the browser.
+// Authorization fails if not completed within timeout.
+func GetClient(ctx context.Context, interactive bool, timeout time.Duration) (*http.Client, error) {
 
 	config, err := getConfig()
 
@@ -33,12 +49,29 @@ func GetClient(ctx context.Context) (*http.Client, error) {
 		return nil, fmt.Errorf("error getting google config: %w", err)
 	}
 
+	store, err := NewTokenStore()
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = migrateLegacyToken(store)
+
+	if err != nil {
+		return nil, err
+	}
+
 	var token *oauth2.Token
+	var authorized bool
 
-	token, err = retrieveTokenFromFile()
+	token, err = store.Get()
 
 	if err != nil {
-		token, err = beginAuthorization(ctx)
+		if !interactive {
+			return nil, ErrAuthorizationRequired
+		}
+
+		token, err = beginAuthorization(ctx, timeout)
 
 		if err != nil {
 
@@ -46,11 +79,17 @@ func GetClient(ctx context.Context) (*http.Client, error) {
 
 		}
 
+		authorized = true
+
 	}
 
-	// TODO no need to reauthorize after n days because refresh token doesn't expire?
-	if time.Now().After(token.Expiry.AddDate(0, 0, 6)) {
-		token, err = beginAuthorization(ctx)
+	/// Without a refresh token an expired access token can't be renewed
+	if !authorized && !token.Valid() && token.RefreshToken == "" {
+		if !interactive {
+			return nil, ErrAuthorizationRequired
+		}
+
+		token, err = beginAuthorization(ctx, timeout)
 
 		if err != nil {
 
@@ -58,15 +97,78 @@ func GetClient(ctx context.Context) (*http.Client, error) {
 
 		}
 
+		authorized = true
+
 	}
 
-	// TODO no need to save if token already exists?
-	saveToken(token)
+	/// Refresh an expired token upfront, so a refresh token which expired or was revoked (invalid_grant) is caught before any request
+	if !authorized && !token.Valid() {
+
+		refreshed, err := config.TokenSource(ctx, token).Token()
+
+		if err != nil {
+
+			if !isInvalidGrant(err) {
+				return nil, fmt.Errorf("error refreshing google token: %w", err)
+			}
+
+			if !interactive {
+				return nil, ErrAuthorizationRequired
+			}
+
+			refreshed, err = beginAuthorization(ctx, timeout)
+
+			if err != nil {
+				return nil, fmt.Errorf("error authorizing with sheets api: %w", err)
+			}
+
+		}
+
+		token = refreshed
+		authorized = true
+
+	}
+
+	if authorized {
+
+		err = store.Set(token)
+
+		if err != nil {
+			return nil, fmt.Errorf("error saving google token: %w", err)
+		}
+
+	}
 
 	return config.Client(ctx, token), nil
 
 }
 
+// Authorize begins authorization in the browser and stores the resulting token, replacing any stored token.
+// Authorization fails if not completed within timeout.
+func Authorize(ctx context.Context, timeout time.Duration) error {
+
+	store, err := NewTokenStore()
+
+	if err != nil {
+		return err
+	}
+
+	token, err := beginAuthorization(ctx, timeout)
+
+	if err != nil {
+		return fmt.Errorf("error authorizing with sheets api: %w", err)
+	}
+
+	err = store.Set(token)
+
+	if err != nil {
+		return fmt.Errorf("error saving google token: %w", err)
+	}
+
+	return nil
+
+}
+
 func getConfig() (*oauth2.Config, error) {
 	credentials, err := GoogleCredentials.ReadFile("credentials.json")
 	if err != nil {
@@ -83,8 +185,7 @@ func getConfig() (*oauth2.Config, error) {
 	return config, nil
 }
 
-// TODO context timeout
-func beginAuthorization(context context.Context) (*oauth2.Token, error) {
+func beginAuthorization(parent context.Context, timeout time.Duration) (*oauth2.Token, error) {
 
 	config, err := getConfig()
 
@@ -145,7 +246,10 @@ func beginAuthorization(context context.Context) (*oauth2.Token, error) {
 		State:                  randomStateValue,
 	}
 
-	eg, ctx := errgroup.WithContext(context)
+	timeoutCtx, cancel := context.WithTimeout(parent, timeout)
+	defer cancel()
+
+	eg, ctx := errgroup.WithContext(timeoutCtx)
 
 	eg.Go(func() error {
 		select {
@@ -171,6 +275,10 @@ func beginAuthorization(context context.Context) (*oauth2.Token, error) {
 
 	if err := eg.Wait(); err != nil {
 
+		if errors.Is(timeoutCtx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("authorization timed out after %s: %w", timeout, err)
+		}
+
 		return nil, fmt.Errorf("authorization error: %w", err)
 	}
 
@@ -0,0 +1,113 @@
+package sheets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/oauth2"
+	"google.golang.org/api/googleapi"
+)
+
+// SheetsError is an error response of the Google Sheets API
+type SheetsError struct {
+	StatusCode int
+	// Message is the message of the API e.g. "Unable to parse range: ..."
+	Message       string
+	SpreadsheetID string
+	// Account is the email of the authorized Google account, set when permission was denied
+	Account string
+
+	err error
+}
+
+func (e *SheetsError) Error() string {
+
+	switch e.StatusCode {
+	case http.StatusUnauthorized:
+		return "google authorization expired or was revoked"
+	case http.StatusForbidden:
+		return fmt.Sprintf("permission denied on spreadsheet %s", e.SpreadsheetID)
+	case http.StatusNotFound:
+		return fmt.Sprintf("spreadsheet %s not found", e.SpreadsheetID)
+	case http.StatusTooManyRequests:
+		return "google sheets rate limit reached"
+	}
+
+	if e.StatusCode >= 500 {
+		return "google sheets is unavailable"
+	}
+
+	if e.Message != "" {
+		return e.Message
+	}
+
+	return "an error occurred"
+
+}
+
+func (e *SheetsError) Unwrap() error {
+	return e.err
+}
+
+// Guidance returns how to resolve the error, or an empty string
+func (e *SheetsError) Guidance() string {
+
+	switch e.StatusCode {
+	case http.StatusUnauthorized:
+		return "Use <wakalog auth google> to reauthorize Google Sheets"
+	case http.StatusForbidden:
+		if e.Account != "" {
+			return fmt.Sprintf("Ask the sheet owner to share it with %s, or use <wakalog auth google> to switch accounts", e.Account)
+		}
+		return "Ask the sheet owner to share it with the Google account you authorized, or use <wakalog auth google> to switch accounts"
+	case http.StatusNotFound:
+		return "Check the spreadsheet ID with <wakalog config get spreadsheet_id>"
+	case http.StatusBadRequest:
+		return "Check the sheet layout matches the spreadsheet"
+	case http.StatusTooManyRequests:
+		return "Try again in a minute"
+	}
+
+	if e.StatusCode >= 500 {
+		return "Try again later"
+	}
+
+	return ""
+
+}
+
+// WrapError converts a Google API error of spreadsheetID to a SheetsError, leaving other errors as they are.
+// When permission was denied, the authorized account is looked up so it can be shared with.
+func WrapError(ctx context.Context, err error, spreadsheetID string) error {
+
+	var apiError *googleapi.Error
+
+	if !errors.As(err, &apiError) {
+		return err
+	}
+
+	sheetsError := &SheetsError{
+		StatusCode:    apiError.Code,
+		Message:       apiError.Message,
+		SpreadsheetID: spreadsheetID,
+		err:           err,
+	}
+
+	if sheetsError.StatusCode == http.StatusForbidden {
+		sheetsError.Account = authorizedEmail(ctx)
+	}
+
+	return sheetsError
+
+}
+
+// isInvalidGrant reports whether err is a token refresh rejected as the refresh token expired or was revoked
+func isInvalidGrant(err error) bool {
+
+	var retrieveError *oauth2.RetrieveError
+
+	return errors.As(err, &retrieveError) && retrieveError.ErrorCode == "invalid_grant"
+
+}
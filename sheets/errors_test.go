@@ -0,0 +1,67 @@
+package sheets
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/googleapi"
+)
+
+func TestSheetsErrorGuidance(t *testing.T) {
+
+	tests := []struct {
+		name         string
+		err          *SheetsError
+		wantError    string
+		wantGuidance string
+	}{
+		{name: "forbidden with account", err: &SheetsError{StatusCode: http.StatusForbidden, SpreadsheetID: "abc", Account: "jane@example.com"}, wantError: "permission denied on spreadsheet abc", wantGuidance: "share it with jane@example.com"},
+		{name: "forbidden without account", err: &SheetsError{StatusCode: http.StatusForbidden, SpreadsheetID: "abc"}, wantError: "permission denied on spreadsheet abc", wantGuidance: "share it with the Google account you authorized"},
+		{name: "not found", err: &SheetsError{StatusCode: http.StatusNotFound, SpreadsheetID: "abc"}, wantError: "spreadsheet abc not found", wantGuidance: "spreadsheet_id"},
+		{name: "rate limited", err: &SheetsError{StatusCode: http.StatusTooManyRequests}, wantError: "rate limit", wantGuidance: "Try again in a minute"},
+		{name: "unavailable", err: &SheetsError{StatusCode: http.StatusBadGateway}, wantError: "unavailable", wantGuidance: "Try again later"},
+		{name: "bad request", err: &SheetsError{StatusCode: http.StatusBadRequest, Message: "Unable to parse range"}, wantError: "Unable to parse range", wantGuidance: "sheet layout"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			if got := tt.err.Error(); !strings.Contains(got, tt.wantError) {
+				t.Errorf("Error() = %q, want it to contain %q", got, tt.wantError)
+			}
+
+			if got := tt.err.Guidance(); !strings.Contains(got, tt.wantGuidance) {
+				t.Errorf("Guidance() = %q, want it to contain %q", got, tt.wantGuidance)
+			}
+
+		})
+	}
+
+}
+
+func TestWrapError(t *testing.T) {
+
+	apiError := &googleapi.Error{Code: http.StatusNotFound, Message: "Requested entity was not found."}
+
+	err := WrapError(context.Background(), apiError, "abc")
+
+	var sheetsError *SheetsError
+
+	if !errors.As(err, &sheetsError) || sheetsError.StatusCode != http.StatusNotFound || sheetsError.SpreadsheetID != "abc" || sheetsError.Message != apiError.Message {
+		t.Fatalf("WrapError() = %#v, want a SheetsError", err)
+	}
+
+	if !errors.Is(err, apiError) {
+		t.Error("WrapError() doesn't wrap the Google API error")
+	}
+
+	other := errors.New("connection refused")
+
+	if got := WrapError(context.Background(), other, "abc"); got != other {
+		t.Errorf("WrapError() = %v, want other errors unchanged", got)
+	}
+
+}
@@ -0,0 +1,421 @@
+package sheets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	sheetsapi "google.golang.org/api/sheets/v4"
+)
+
+// Metrics that can be written within a week block
+const (
+	MetricDailyAverage  = "daily_average"
+	MetricMostActiveDay = "most_active_day"
+	MetricTotal         = "total"
+	// Top languages, editors and categories of the selected projects with hours e.g. "Go (12.5h), TypeScript (3.0h)"
+	MetricTopLanguages  = "top_languages"
+	MetricTopEditors    = "top_editors"
+	MetricTopCategories = "top_categories"
+)
+
+// DefaultTopN is the number of languages, editors or categories written by breakdown metrics when not configured
+const DefaultTopN = 3
+
+var metricLabels = map[string]string{
+	MetricDailyAverage:  "Daily Average",
+	MetricMostActiveDay: "Most Active Day",
+	MetricTotal:         "Total",
+	MetricTopLanguages:  "Top Languages",
+	MetricTopEditors:    "Top Editors",
+	MetricTopCategories: "Top Categories",
+}
+
+// Tab resolution modes
+const (
+	// TabByTitle picks the month tab by matching its title against the month name
+	TabByTitle = "title"
+	// TabByIndex picks the month tab by its position in the spreadsheet
+	TabByIndex = "index"
+)
+
+// defaultTabFormats are matched against tab titles when no format is configured, in order
+var defaultTabFormats = []string{"January 2006", "Jan 2006", "January", "Jan"}
+
+// ErrTabNotFound is returned when no tab matches the month being logged
+var ErrTabNotFound = errors.New("month tab not found")
+
+// Layout describes where names are read from and where weekly summaries are written on the spreadsheet
+type Layout struct {
+	// NameColumn is the column holding the names of users e.g. B
+	NameColumn string `json:"name_column"`
+	// HeaderRows is the number of rows above the first user's row
+	HeaderRows int `json:"header_rows"`
+	// WeekColumns are the first columns of each week block in a month tab e.g. C, G, K, O, S
+	WeekColumns []string `json:"week_columns"`
+	// Metrics are written to consecutive cells from the first column of a week block. An empty metric leaves its cell untouched.
+	Metrics []string `json:"metrics"`
+	// TopN is the number of languages, editors or categories written by breakdown metrics
+	TopN int `json:"top_n,omitempty"`
+	// Tabs describes how the tab of a month is resolved
+	Tabs TabLayout `json:"tabs"`
+}
+
+type TabLayout struct {
+	// Mode is the tab resolution mode
+	Mode string `json:"mode"`
+	// Format is the Go time layout of month tab titles when resolving by title e.g. "January" or "Jan 2006".
+	// When empty, titles such as "January 2026", "Jan 2026", "January" and "Jan" are matched.
+	Format string `json:"format,omitempty"`
+	// FirstIndex is the index of January's tab when resolving by index
+	FirstIndex int `json:"first_index,omitempty"`
+	// Template is the title of the tab duplicated when creating a missing month tab
+	Template string `json:"template,omitempty"`
+	// Create enables creating a missing month tab from Template
+	Create bool `json:"create,omitempty"`
+}
+
+// DefaultLayout returns the layout of the default spreadsheet
+func DefaultLayout() *Layout {
+
+	return &Layout{
+		NameColumn:  "B",
+		HeaderRows:  2,
+		WeekColumns: []string{"C", "G", "K", "O", "S"}, // representing 5 possible weeks in a month
+		Metrics:     []string{MetricDailyAverage, MetricMostActiveDay, MetricTotal},
+		Tabs: TabLayout{
+			Mode: TabByTitle,
+		},
+	}
+
+}
+
+// LoadLayout reads a JSON layout file. Fields omitted in the file take their value from DefaultLayout.
+func LoadLayout(path string) (*Layout, error) {
+
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading layout file: %w", err)
+	}
+
+	layout := DefaultLayout()
+
+	err = json.Unmarshal(data, layout)
+
+	if err != nil {
+		return nil, fmt.Errorf("error parsing layout file %s: %w", path, err)
+	}
+
+	return layout, nil
+
+}
+
+// Validate checks the layout is well formed
+func (l *Layout) Validate() error {
+
+	var errs []error
+
+	if _, err := ColumnIndex(l.NameColumn); err != nil {
+		errs = append(errs, fmt.Errorf("name_column: %w", err))
+	}
+
+	if l.HeaderRows < 0 {
+		errs = append(errs, fmt.Errorf("header_rows must not be negative"))
+	}
+
+	if l.TopN < 0 {
+		errs = append(errs, fmt.Errorf("top_n must not be negative"))
+	}
+
+	if len(l.WeekColumns) == 0 {
+		errs = append(errs, fmt.Errorf("week_columns must not be empty"))
+	}
+
+	for _, column := range l.WeekColumns {
+		if _, err := ColumnIndex(column); err != nil {
+			errs = append(errs, fmt.Errorf("week_columns: %w", err))
+		}
+	}
+
+	if !slices.ContainsFunc(l.Metrics, func(metric string) bool { return metric != "" }) {
+		errs = append(errs, fmt.Errorf("metrics must contain at least one metric"))
+	}
+
+	for _, metric := range l.Metrics {
+		if _, ok := metricLabels[metric]; metric != "" && !ok {
+			errs = append(errs, fmt.Errorf("metrics: unknown metric %q", metric))
+		}
+	}
+
+	switch l.Tabs.Mode {
+	case TabByTitle:
+		if l.Tabs.Create && l.Tabs.Template == "" {
+			errs = append(errs, fmt.Errorf("tabs.template is required to create month tabs"))
+		}
+	case TabByIndex:
+		if l.Tabs.FirstIndex < 0 {
+			errs = append(errs, fmt.Errorf("tabs.first_index must not be negative"))
+		}
+		if l.Tabs.Create {
+			errs = append(errs, fmt.Errorf("tabs.create is only supported with mode %q", TabByTitle))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("tabs.mode: unknown mode %q", l.Tabs.Mode))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid sheet layout: %w", errors.Join(errs...))
+	}
+
+	return nil
+
+}
+
+// ValidateSheet checks the layout fits within the grid of a month tab
+func (l *Layout) ValidateSheet(properties *sheetsapi.SheetProperties) error {
+
+	if properties.GridProperties == nil {
+		return fmt.Errorf("tab %q is not a grid", properties.Title)
+	}
+
+	lastColumn, err := l.lastColumnIndex()
+
+	if err != nil {
+		return err
+	}
+
+	if columnCount := properties.GridProperties.ColumnCount; int64(lastColumn) >= columnCount {
+		return fmt.Errorf("sheet layout does not match tab %q: layout needs column %s but tab has %d columns", properties.Title, ColumnName(lastColumn), columnCount)
+	}
+
+	if rowCount := properties.GridProperties.RowCount; int64(l.HeaderRows) >= rowCount {
+		return fmt.Errorf("sheet layout does not match tab %q: layout has %d header rows but tab has %d rows", properties.Title, l.HeaderRows, rowCount)
+	}
+
+	return nil
+
+}
+
+// ResolveTab returns the tab of the month of date. ErrTabNotFound is returned when no tab matches.
+func (l *Layout) ResolveTab(spreadsheet *sheetsapi.Spreadsheet, date time.Time) (*sheetsapi.SheetProperties, error) {
+
+	if l.Tabs.Mode == TabByIndex {
+
+		index := l.Tabs.FirstIndex + int(date.Month()) - 1
+
+		if index >= len(spreadsheet.Sheets) {
+			return nil, fmt.Errorf("%w: no tab at index %d for %s, spreadsheet has %d tabs", ErrTabNotFound, index, date.Format("January"), len(spreadsheet.Sheets))
+		}
+
+		return spreadsheet.Sheets[index].Properties, nil
+
+	}
+
+	formats := defaultTabFormats
+
+	if l.Tabs.Format != "" {
+		formats = []string{l.Tabs.Format}
+	}
+
+	/// Formats are matched in order so a more specific title (e.g. "January 2026") is preferred over "January"
+	for _, format := range formats {
+
+		title := date.Format(format)
+
+		for _, s := range spreadsheet.Sheets {
+			if strings.EqualFold(strings.TrimSpace(s.Properties.Title), title) {
+				return s.Properties, nil
+			}
+		}
+
+	}
+
+	titles := make([]string, len(spreadsheet.Sheets))
+
+	for i, s := range spreadsheet.Sheets {
+		titles[i] = fmt.Sprintf("%q", s.Properties.Title)
+	}
+
+	return nil, fmt.Errorf("%w: no tab titled %q for %s, spreadsheet has tabs %s", ErrTabNotFound, l.TabTitle(date), date.Format("January 2006"), strings.Join(titles, ", "))
+
+}
+
+// TabTitle returns the title of the month tab of date, used when creating it
+func (l *Layout) TabTitle(date time.Time) string {
+
+	if l.Tabs.Format != "" {
+		return date.Format(l.Tabs.Format)
+	}
+
+	return date.Format(defaultTabFormats[0])
+
+}
+
+// CreateTab duplicates the template tab as the month tab of date, appending the new tab to spreadsheet.Sheets
+func (l *Layout) CreateTab(ctx context.Context, srv *sheetsapi.Service, spreadsheet *sheetsapi.Spreadsheet, date time.Time) (*sheetsapi.SheetProperties, error) {
+
+	i := slices.IndexFunc(spreadsheet.Sheets, func(s *sheetsapi.Sheet) bool {
+		return strings.EqualFold(strings.TrimSpace(s.Properties.Title), l.Tabs.Template)
+	})
+
+	if i < 0 {
+		return nil, fmt.Errorf("template tab %q not found", l.Tabs.Template)
+	}
+
+	request := &sheetsapi.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheetsapi.Request{
+			{
+				DuplicateSheet: &sheetsapi.DuplicateSheetRequest{
+					SourceSheetId:    spreadsheet.Sheets[i].Properties.SheetId,
+					NewSheetName:     l.TabTitle(date),
+					InsertSheetIndex: int64(len(spreadsheet.Sheets)),
+				},
+			},
+		},
+	}
+
+	resp, err := srv.Spreadsheets.BatchUpdate(spreadsheet.SpreadsheetId, request).Context(ctx).Do()
+
+	if err != nil {
+		return nil, fmt.Errorf("error duplicating template tab %q: %w", l.Tabs.Template, WrapError(ctx, err, spreadsheet.SpreadsheetId))
+	}
+
+	if len(resp.Replies) == 0 || resp.Replies[0].DuplicateSheet == nil {
+		return nil, fmt.Errorf("error duplicating template tab %q: empty response", l.Tabs.Template)
+	}
+
+	properties := resp.Replies[0].DuplicateSheet.Properties
+
+	spreadsheet.Sheets = append(spreadsheet.Sheets, &sheetsapi.Sheet{Properties: properties})
+
+	return properties, nil
+
+}
+
+// NamesRange returns the range of names on a tab e.g. January!B3:B
+func (l *Layout) NamesRange(tab string) string {
+	return fmt.Sprintf("%s!%s%d:%s", quoteTab(tab), l.NameColumn, l.FirstRow(), l.NameColumn)
+}
+
+// FirstRow returns the row number of the first user
+func (l *Layout) FirstRow() int {
+	return l.HeaderRows + 1
+}
+
+// BlockRange returns the range of the metrics of a week block on a row e.g. January!C3:E3
+func (l *Layout) BlockRange(tab string, week int, row int) (string, error) {
+
+	if week < 0 || week >= len(l.WeekColumns) {
+		return "", fmt.Errorf("week %d of the month has no block: layout has %d week columns", week+1, len(l.WeekColumns))
+	}
+
+	start, err := ColumnIndex(l.WeekColumns[week])
+
+	if err != nil {
+		return "", err
+	}
+
+	end := start + len(l.Metrics) - 1
+
+	return fmt.Sprintf("%s!%s%d:%s%d", quoteTab(tab), ColumnName(start), row, ColumnName(end), row), nil
+
+}
+
+// HasBreakdown reports whether the layout includes top languages, editors or categories
+func (l *Layout) HasBreakdown() bool {
+
+	return slices.ContainsFunc(l.Metrics, func(metric string) bool {
+		return metric == MetricTopLanguages || metric == MetricTopEditors || metric == MetricTopCategories
+	})
+
+}
+
+// TopCount returns the number of items written by breakdown metrics
+func (l *Layout) TopCount() int {
+
+	if l.TopN == 0 {
+		return DefaultTopN
+	}
+
+	return l.TopN
+
+}
+
+// MetricLabel returns the human readable name of a metric
+func MetricLabel(metric string) string {
+	return metricLabels[metric]
+}
+
+func (l *Layout) lastColumnIndex() (int, error) {
+
+	last, err := ColumnIndex(l.NameColumn)
+
+	if err != nil {
+		return 0, err
+	}
+
+	for _, column := range l.WeekColumns {
+
+		start, err := ColumnIndex(column)
+
+		if err != nil {
+			return 0, err
+		}
+
+		last = max(last, start+len(l.Metrics)-1)
+
+	}
+
+	return last, nil
+
+}
+
+// ColumnIndex converts a column name to its zero based index e.g. A is 0, AA is 26
+func ColumnIndex(column string) (int, error) {
+
+	column = strings.ToUpper(strings.TrimSpace(column))
+
+	if column == "" {
+		return 0, fmt.Errorf("empty column")
+	}
+
+	index := 0
+
+	for _, r := range column {
+
+		if r < 'A' || r > 'Z' {
+			return 0, fmt.Errorf("invalid column %q", column)
+		}
+
+		index = index*26 + int(r-'A'+1)
+
+	}
+
+	return index - 1, nil
+
+}
+
+// ColumnName converts a zero based column index to its name e.g. 0 is A, 26 is AA
+func ColumnName(index int) string {
+
+	var name []byte
+
+	for index >= 0 {
+		name = append([]byte{byte('A' + index%26)}, name...)
+		index = index/26 - 1
+	}
+
+	return string(name)
+
+}
+
+// quoteTab quotes a tab title for use in A1 notation
+func quoteTab(tab string) string {
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(tab, "'", "''"))
+}
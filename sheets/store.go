@@ -2,42 +2,242 @@ package sheets
 
 import (
 	"encoding/json"
-	"log"
+	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
 
+	"github.com/zalando/go-keyring"
 	"golang.org/x/oauth2"
 )
 
-var tokenPath = "token.json"
+var serviceName = "wakalog"
+var userName = "google_oauth_token"
 
-// TODO token from keystring?
-func retrieveTokenFromFile() (*oauth2.Token, error) {
+// legacyTokenPath is where tokens were stored before token stores, relative to the working directory
+var legacyTokenPath = "token.json"
 
-	tokenFile, err := os.Open(tokenPath)
+// ErrTokenNotFound is returned by a TokenStore without a stored token
+var ErrTokenNotFound = errors.New("google token not found")
+
+// TokenStore persists the Google OAuth token
+type TokenStore interface {
+	// Get returns the stored token, or ErrTokenNotFound
+	Get() (*oauth2.Token, error)
+	Set(token *oauth2.Token) error
+	// Delete removes the stored token. Deleting a missing token is not an error.
+	Delete() error
+}
+
+// NewTokenStore returns a store saving the token in the OS keyring, falling back to a file in the user config directory
+// when the keyring is unavailable (e.g. no secret service on Linux)
+func NewTokenStore() (TokenStore, error) {
+
+	dir, err := os.UserConfigDir()
+
+	if err != nil {
+		return nil, fmt.Errorf("error locating user config directory: %w", err)
+	}
+
+	return &fallbackTokenStore{
+		primary:  &KeyringTokenStore{},
+		fallback: &FileTokenStore{Path: filepath.Join(dir, "wakalog", "token.json")},
+	}, nil
+
+}
+
+// KeyringTokenStore stores the token in the OS keyring
+type KeyringTokenStore struct{}
+
+func (s *KeyringTokenStore) Get() (*oauth2.Token, error) {
+
+	data, err := keyring.Get(serviceName, userName)
+
+	if err != nil {
+		if errors.Is(err, keyring.ErrNotFound) {
+			return nil, ErrTokenNotFound
+		}
+		return nil, fmt.Errorf("error retrieving google token from keyring: %w", err)
+	}
+
+	token := &oauth2.Token{}
+
+	err = json.Unmarshal([]byte(data), token)
+
+	if err != nil {
+		return nil, fmt.Errorf("error decoding google token: %w", err)
+	}
+
+	return token, nil
+
+}
+
+func (s *KeyringTokenStore) Set(token *oauth2.Token) error {
+
+	data, err := json.Marshal(token)
 
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("error encoding google token: %w", err)
+	}
+
+	err = keyring.Set(serviceName, userName, string(data))
+
+	if err != nil {
+		return fmt.Errorf("error storing google token in keyring: %w", err)
+	}
+
+	return nil
+
+}
+
+func (s *KeyringTokenStore) Delete() error {
+
+	err := keyring.Delete(serviceName, userName)
+
+	if err != nil && !errors.Is(err, keyring.ErrNotFound) {
+		return fmt.Errorf("error deleting google token from keyring: %w", err)
+	}
+
+	return nil
+
+}
+
+// FileTokenStore stores the token as JSON in a file readable only by the user
+type FileTokenStore struct {
+	Path string
+}
+
+func (s *FileTokenStore) Get() (*oauth2.Token, error) {
+
+	tokenFile, err := os.Open(s.Path)
+
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrTokenNotFound
+		}
+		return nil, fmt.Errorf("error opening token file: %w", err)
 	}
 
 	defer tokenFile.Close()
 
-	tok := &oauth2.Token{}
+	token := &oauth2.Token{}
+
+	err = json.NewDecoder(tokenFile).Decode(token)
 
-	err = json.NewDecoder(tokenFile).Decode(tok)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding token file %s: %w", s.Path, err)
+	}
 
-	return tok, err
+	return token, nil
 
 }
 
-func saveToken(token *oauth2.Token) {
+func (s *FileTokenStore) Set(token *oauth2.Token) error {
+
+	err := os.MkdirAll(filepath.Dir(s.Path), 0700)
+
+	if err != nil {
+		return fmt.Errorf("error creating token directory: %w", err)
+	}
 
-	f, err := os.OpenFile(tokenPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	f, err := os.OpenFile(s.Path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
 
 	if err != nil {
-		log.Fatalf("Unable to cache oauth token: %v", err)
+		return fmt.Errorf("error opening token file: %w", err)
 	}
 
 	defer f.Close()
 
-	json.NewEncoder(f).Encode(token)
+	err = json.NewEncoder(f).Encode(token)
+
+	if err != nil {
+		return fmt.Errorf("error writing token file: %w", err)
+	}
+
+	return nil
+
+}
+
+func (s *FileTokenStore) Delete() error {
+
+	err := os.Remove(s.Path)
+
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error deleting token file: %w", err)
+	}
+
+	return nil
+
+}
+
+// fallbackTokenStore uses primary, switching to fallback when primary fails
+type fallbackTokenStore struct {
+	primary  TokenStore
+	fallback TokenStore
+}
+
+func (s *fallbackTokenStore) Get() (*oauth2.Token, error) {
+
+	token, err := s.primary.Get()
+
+	if err == nil {
+		return token, nil
+	}
+
+	/// The token may have been saved to the fallback store when primary was unavailable
+	return s.fallback.Get()
+
+}
+
+func (s *fallbackTokenStore) Set(token *oauth2.Token) error {
+
+	if err := s.primary.Set(token); err != nil {
+		return s.fallback.Set(token)
+	}
+
+	/// Remove any stale token from the fallback store
+	return s.fallback.Delete()
+
+}
+
+func (s *fallbackTokenStore) Delete() error {
+
+	/// Like Set and Get, an unavailable primary means the token can only be in the fallback store
+	s.primary.Delete()
+
+	return s.fallback.Delete()
+
+}
+
+// migrateLegacyToken moves a token.json left in the working directory by earlier versions into store,
+// renaming it to token.json.migrated. Files which don't hold a Google token are left untouched.
+func migrateLegacyToken(store TokenStore) error {
+
+	legacy := &FileTokenStore{Path: legacyTokenPath}
+
+	token, err := legacy.Get()
+
+	/// A token.json in the working directory may belong to something else
+	if err != nil || (token.AccessToken == "" && token.RefreshToken == "") {
+		return nil
+	}
+
+	if _, err := store.Get(); err == nil {
+		return nil // keep the already stored token, leaving the legacy file untouched
+	}
+
+	err = store.Set(token)
+
+	if err != nil {
+		return fmt.Errorf("error migrating %s: %w", legacyTokenPath, err)
+	}
+
+	err = os.Rename(legacyTokenPath, legacyTokenPath+".migrated")
+
+	if err != nil {
+		return fmt.Errorf("error renaming %s: %w", legacyTokenPath, err)
+	}
+
+	return nil
+
 }
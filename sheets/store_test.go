@@ -0,0 +1,110 @@
+package sheets
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+// unavailableTokenStore fails like a keyring without a secret service
+type unavailableTokenStore struct{}
+
+var errUnavailable = errors.New("keyring unavailable")
+
+func (s unavailableTokenStore) Get() (*oauth2.Token, error) { return nil, errUnavailable }
+func (s unavailableTokenStore) Set(*oauth2.Token) error     { return errUnavailable }
+func (s unavailableTokenStore) Delete() error               { return errUnavailable }
+
+func TestFallbackTokenStoreUnavailablePrimary(t *testing.T) {
+
+	fallback := &FileTokenStore{Path: filepath.Join(t.TempDir(), "token.json")}
+	store := &fallbackTokenStore{primary: unavailableTokenStore{}, fallback: fallback}
+
+	err := store.Set(&oauth2.Token{AccessToken: "access", RefreshToken: "refresh"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := store.Get()
+
+	if err != nil || token.RefreshToken != "refresh" {
+		t.Fatalf("Get() = %v, %v, want the token from the fallback store", token, err)
+	}
+
+	err = store.Delete()
+
+	if err != nil {
+		t.Fatalf("Delete() = %v, want nil", err)
+	}
+
+	if _, err := fallback.Get(); !errors.Is(err, ErrTokenNotFound) {
+		t.Errorf("fallback Get() after Delete() = %v, want ErrTokenNotFound", err)
+	}
+
+}
+
+func TestMigrateLegacyToken(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		content     string
+		wantMigrate bool
+	}{
+		{name: "google token", content: `{"access_token":"access","refresh_token":"refresh"}`, wantMigrate: true},
+		{name: "token without credentials", content: `{"token":"something else"}`},
+		{name: "not json", content: `not a token`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			dir := t.TempDir()
+
+			path := filepath.Join(dir, "token.json")
+
+			if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			previous := legacyTokenPath
+			legacyTokenPath = path
+			t.Cleanup(func() { legacyTokenPath = previous })
+
+			store := &FileTokenStore{Path: filepath.Join(dir, "wakalog", "token.json")}
+
+			if err := migrateLegacyToken(store); err != nil {
+				t.Fatal(err)
+			}
+
+			token, err := store.Get()
+
+			if tt.wantMigrate {
+
+				if err != nil || token.RefreshToken != "refresh" {
+					t.Errorf("store Get() = %v, %v, want the migrated token", token, err)
+				}
+
+				if _, err := os.Stat(path + ".migrated"); err != nil {
+					t.Errorf("legacy token not renamed: %v", err)
+				}
+
+			} else {
+
+				if !errors.Is(err, ErrTokenNotFound) {
+					t.Errorf("store Get() = %v, %v, want ErrTokenNotFound", token, err)
+				}
+
+				if data, err := os.ReadFile(path); err != nil || string(data) != tt.content {
+					t.Errorf("legacy file changed: %q, %v", data, err)
+				}
+
+			}
+
+		})
+	}
+
+}
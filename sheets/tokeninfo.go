@@ -0,0 +1,100 @@
+package sheets
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Youngtard/wakalog/httpclient"
+)
+
+var tokenInfoURL = "https://oauth2.googleapis.com/tokeninfo"
+
+// TokenInfo describes the stored Google token
+type TokenInfo struct {
+	// Expiry is when the current access token expires
+	Expiry          time.Time
+	HasRefreshToken bool
+	Scopes          []string
+	MissingScopes   []string
+	// Email is the email of the authorized account, empty if authorized without the email scope
+	Email string
+}
+
+type tokenInfoResponse struct {
+	Scope string `json:"scope"`
+	Email string `json:"email"`
+}
+
+// GetTokenInfo returns details of the stored token, refreshing its access token if expired and checking its scopes with Google.
+// ErrTokenNotFound is returned when no token is stored.
+func GetTokenInfo(ctx context.Context) (*TokenInfo, error) {
+
+	store, err := NewTokenStore()
+
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := store.Get()
+
+	if err != nil {
+		return nil, err
+	}
+
+	info := &TokenInfo{
+		HasRefreshToken: token.RefreshToken != "",
+	}
+
+	config, err := getConfig()
+
+	if err != nil {
+		return nil, fmt.Errorf("error getting google config: %w", err)
+	}
+
+	freshToken, err := config.TokenSource(ctx, token).Token()
+
+	if err != nil {
+		return nil, fmt.Errorf("error refreshing google token: %w", err)
+	}
+
+	info.Expiry = freshToken.Expiry
+
+	values := url.Values{}
+	values.Add("access_token", freshToken.AccessToken)
+
+	resp := new(tokenInfoResponse)
+
+	_, err = httpclient.NewClient(nil).Get(ctx, tokenInfoURL, values, resp)
+
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving google token info: %w", err)
+	}
+
+	info.Scopes = strings.Fields(resp.Scope)
+	info.Email = resp.Email
+
+	/// Full access to spreadsheets covers the readonly scope
+	if !slices.Contains(info.Scopes, spreadsheetsScope) {
+		info.MissingScopes = append(info.MissingScopes, spreadsheetsScope)
+	}
+
+	return info, nil
+
+}
+
+// authorizedEmail returns the email of the authorized account, or an empty string if unknown
+func authorizedEmail(ctx context.Context) string {
+
+	info, err := GetTokenInfo(ctx)
+
+	if err != nil {
+		return ""
+	}
+
+	return info.Email
+
+}
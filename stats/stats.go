@@ -0,0 +1,197 @@
+// Package stats aggregates WakaTime summaries into weekly reports, independently of where they are logged
+package stats
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Youngtard/wakalog/wakatime"
+)
+
+// Day is the time spent on the selected projects on a day
+type Day struct {
+	Date  time.Time
+	Total time.Duration
+	// Projects is the time spent on each selected project worked on during the day
+	Projects map[string]time.Duration
+}
+
+// ProjectTotal is the time spent on a project over a week
+type ProjectTotal struct {
+	Name  string
+	Total time.Duration
+}
+
+// WeeklyReport aggregates the days of a week
+type WeeklyReport struct {
+	Days  []Day
+	Total time.Duration
+	// DaysWorked is the number of days with activity. As on WakaTime, days without activity are excluded from the daily average and median.
+	DaysWorked   int
+	DailyAverage time.Duration
+	Median       time.Duration
+	// MostActiveDay is the index of the day with the most activity within Days (the earliest on a tie), or -1 without activity
+	MostActiveDay int
+	// LongestStreak is the most consecutive days with activity. Weekend days without activity don't break a streak.
+	LongestStreak int
+	// Projects are the selected projects worked on, by most time spent
+	Projects []ProjectTotal
+}
+
+// HasActivity reports whether any time was spent during the week
+func (r *WeeklyReport) HasActivity() bool {
+	return r.DaysWorked > 0
+}
+
+// DaysFromSummaries returns the time spent on projects for each day of summaries, starting on start
+func DaysFromSummaries(summaries *wakatime.Summaries, start time.Time, projects []string) []Day {
+
+	days := make([]Day, len(summaries.Data))
+
+	for i, data := range summaries.Data {
+
+		day := Day{Date: DayDate(data, start, i), Projects: map[string]time.Duration{}}
+
+		for _, project := range data.Projects {
+
+			if !slices.Contains(projects, project.Name) {
+				continue
+			}
+
+			totalTime := seconds(project.TotalSeconds)
+
+			day.Projects[project.Name] += totalTime
+			day.Total += totalTime
+
+		}
+
+		days[i] = day
+
+	}
+
+	return days
+
+}
+
+// ProjectNames returns the unique projects worked on during summaries, in order of appearance
+func ProjectNames(summaries *wakatime.Summaries) []string {
+
+	var projects []string
+
+	for _, data := range summaries.Data {
+
+		for _, project := range data.Projects {
+
+			if !slices.Contains(projects, project.Name) {
+				projects = append(projects, project.Name)
+			}
+
+		}
+
+	}
+
+	return projects
+
+}
+
+// NewWeeklyReport aggregates days
+func NewWeeklyReport(days []Day) *WeeklyReport {
+
+	report := &WeeklyReport{Days: days, MostActiveDay: -1}
+
+	projectTotals := map[string]time.Duration{}
+
+	var worked []time.Duration
+	var streak int
+
+	for i, day := range days {
+
+		for name, total := range day.Projects {
+			projectTotals[name] += total
+		}
+
+		// Skip days where user had no coding activity.
+		// Daily Average computation according to WakaTime ignores days of no activity
+		if day.Total <= time.Duration(0) {
+
+			if !isWeekend(day.Date) {
+				streak = 0
+			}
+
+			continue
+		}
+
+		if report.MostActiveDay == -1 || day.Total > days[report.MostActiveDay].Total {
+			report.MostActiveDay = i
+		}
+
+		streak++
+		report.LongestStreak = max(report.LongestStreak, streak)
+
+		report.DaysWorked += 1
+		report.Total += day.Total
+
+		worked = append(worked, day.Total)
+
+	}
+
+	if report.DaysWorked > 0 {
+		report.DailyAverage = report.Total / time.Duration(report.DaysWorked)
+		report.Median = median(worked)
+	}
+
+	for name, total := range projectTotals {
+		report.Projects = append(report.Projects, ProjectTotal{Name: name, Total: total})
+	}
+
+	slices.SortFunc(report.Projects, func(a, b ProjectTotal) int {
+		if a.Total != b.Total {
+			if a.Total > b.Total {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return report
+
+}
+
+// median returns the median of durations, the mean of the middle two with an even count
+func median(durations []time.Duration) time.Duration {
+
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+
+	middle := len(sorted) / 2
+
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+
+	return sorted[middle]
+
+}
+
+func isWeekend(date time.Time) bool {
+	return date.Weekday() == time.Saturday || date.Weekday() == time.Sunday
+}
+
+// seconds converts seconds as reported by WakaTime to a duration
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+// DayDate returns the date of data, the i-th day of summaries, as reported by WakaTime in the requested timezone.
+// Falls back to counting days from start.
+func DayDate(data wakatime.SummariesData, start time.Time, i int) time.Time {
+
+	if date, err := time.Parse("2006-01-02", data.Range.Date); err == nil {
+		return date
+	}
+
+	return start.AddDate(0, 0, i)
+
+}
@@ -0,0 +1,198 @@
+package stats
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Youngtard/wakalog/wakatime"
+)
+
+// week returns days starting on Monday 2026-10-12 with totals in minutes
+func week(minutes ...int) []Day {
+
+	start := time.Date(2026, time.October, 12, 0, 0, 0, 0, time.UTC)
+
+	days := make([]Day, len(minutes))
+
+	for i, m := range minutes {
+		total := time.Duration(m) * time.Minute
+		days[i] = Day{Date: start.AddDate(0, 0, i), Total: total, Projects: map[string]time.Duration{}}
+		if total > 0 {
+			days[i].Projects["wakalog"] = total
+		}
+	}
+
+	return days
+
+}
+
+func TestNewWeeklyReport(t *testing.T) {
+
+	tests := []struct {
+		name              string
+		days              []Day
+		wantTotal         time.Duration
+		wantDaysWorked    int
+		wantDailyAverage  time.Duration
+		wantMedian        time.Duration
+		wantMostActiveDay int
+		wantLongestStreak int
+	}{
+		{name: "empty week", days: nil, wantMostActiveDay: -1},
+		{name: "all zero week", days: week(0, 0, 0, 0, 0), wantMostActiveDay: -1},
+		{name: "odd median", days: week(60, 0, 30, 90, 0), wantTotal: 180 * time.Minute, wantDaysWorked: 3, wantDailyAverage: 60 * time.Minute, wantMedian: 60 * time.Minute, wantMostActiveDay: 3, wantLongestStreak: 2},
+		{name: "even median", days: week(10, 20, 30, 60, 0), wantTotal: 120 * time.Minute, wantDaysWorked: 4, wantDailyAverage: 30 * time.Minute, wantMedian: 25 * time.Minute, wantMostActiveDay: 3, wantLongestStreak: 4},
+		{name: "tie on most active day is the earliest", days: week(30, 90, 10, 90, 90), wantTotal: 310 * time.Minute, wantDaysWorked: 5, wantDailyAverage: 62 * time.Minute, wantMedian: 90 * time.Minute, wantMostActiveDay: 1, wantLongestStreak: 5},
+		{name: "weekday gap breaks streak", days: week(10, 10, 0, 10, 10, 0, 0), wantTotal: 40 * time.Minute, wantDaysWorked: 4, wantDailyAverage: 10 * time.Minute, wantMedian: 10 * time.Minute, wantMostActiveDay: 0, wantLongestStreak: 2},
+		{name: "weekend gap doesn't break streak", days: week(0, 0, 10, 10, 10, 0, 0, 10, 10), wantTotal: 50 * time.Minute, wantDaysWorked: 5, wantDailyAverage: 10 * time.Minute, wantMedian: 10 * time.Minute, wantMostActiveDay: 2, wantLongestStreak: 5},
+		{name: "weekend activity extends streak", days: week(0, 0, 0, 0, 10, 20, 30), wantTotal: 60 * time.Minute, wantDaysWorked: 3, wantDailyAverage: 20 * time.Minute, wantMedian: 20 * time.Minute, wantMostActiveDay: 6, wantLongestStreak: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			report := NewWeeklyReport(tt.days)
+
+			if report.Total != tt.wantTotal {
+				t.Errorf("Total = %s, want %s", report.Total, tt.wantTotal)
+			}
+
+			if report.DaysWorked != tt.wantDaysWorked {
+				t.Errorf("DaysWorked = %d, want %d", report.DaysWorked, tt.wantDaysWorked)
+			}
+
+			if report.DailyAverage != tt.wantDailyAverage {
+				t.Errorf("DailyAverage = %s, want %s", report.DailyAverage, tt.wantDailyAverage)
+			}
+
+			if math.IsNaN(report.DailyAverage.Hours()) || math.IsNaN(report.Median.Hours()) {
+				t.Errorf("DailyAverage = %s, Median = %s, want numbers", report.DailyAverage, report.Median)
+			}
+
+			if report.Median != tt.wantMedian {
+				t.Errorf("Median = %s, want %s", report.Median, tt.wantMedian)
+			}
+
+			if report.MostActiveDay != tt.wantMostActiveDay {
+				t.Errorf("MostActiveDay = %d, want %d", report.MostActiveDay, tt.wantMostActiveDay)
+			}
+
+			if report.LongestStreak != tt.wantLongestStreak {
+				t.Errorf("LongestStreak = %d, want %d", report.LongestStreak, tt.wantLongestStreak)
+			}
+
+			if report.HasActivity() != (tt.wantDaysWorked > 0) {
+				t.Errorf("HasActivity() = %t, want %t", report.HasActivity(), tt.wantDaysWorked > 0)
+			}
+
+		})
+	}
+
+}
+
+func TestNewWeeklyReportProjects(t *testing.T) {
+
+	days := week(0, 0)
+	days[0].Projects = map[string]time.Duration{"api": time.Hour, "wakalog": time.Hour}
+	days[0].Total = 2 * time.Hour
+	days[1].Projects = map[string]time.Duration{"dotfiles": 3 * time.Hour}
+	days[1].Total = 3 * time.Hour
+
+	report := NewWeeklyReport(days)
+
+	want := []ProjectTotal{{"dotfiles", 3 * time.Hour}, {"api", time.Hour}, {"wakalog", time.Hour}}
+
+	if len(report.Projects) != len(want) {
+		t.Fatalf("Projects = %v, want %v", report.Projects, want)
+	}
+
+	for i := range want {
+		if report.Projects[i] != want[i] {
+			t.Errorf("Projects[%d] = %v, want %v", i, report.Projects[i], want[i])
+		}
+	}
+
+}
+
+func TestDaysFromSummaries(t *testing.T) {
+
+	start := time.Date(2026, time.October, 12, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		data      []wakatime.SummariesData
+		projects  []string
+		wantDates []string
+		wantTotal []time.Duration
+	}{
+		{
+			name: "total seconds are used rather than hours, minutes and seconds",
+			data: []wakatime.SummariesData{
+				{Range: wakatime.SummaryRange{Date: "2026-10-12"}, Projects: []wakatime.Project{{Name: "wakalog", TotalSeconds: 5400.5, Hours: 9, Minutes: 9, Seconds: 9}}},
+			},
+			projects:  []string{"wakalog"},
+			wantDates: []string{"2026-10-12"},
+			wantTotal: []time.Duration{5400*time.Second + 500*time.Millisecond},
+		},
+		{
+			name: "only selected projects are counted",
+			data: []wakatime.SummariesData{
+				{Range: wakatime.SummaryRange{Date: "2026-10-12"}, Projects: []wakatime.Project{{Name: "wakalog", TotalSeconds: 600}, {Name: "dotfiles", TotalSeconds: 1200}, {Name: "api", TotalSeconds: 60}}},
+			},
+			projects:  []string{"wakalog", "api"},
+			wantDates: []string{"2026-10-12"},
+			wantTotal: []time.Duration{11 * time.Minute},
+		},
+		{
+			name: "dates fall back to counting from start",
+			data: []wakatime.SummariesData{
+				{Range: wakatime.SummaryRange{Date: "2026-10-12"}},
+				{},
+				{Range: wakatime.SummaryRange{Date: "2026-10-14"}, Projects: []wakatime.Project{{Name: "wakalog", TotalSeconds: 60}}},
+			},
+			projects:  []string{"wakalog"},
+			wantDates: []string{"2026-10-12", "2026-10-13", "2026-10-14"},
+			wantTotal: []time.Duration{0, 0, time.Minute},
+		},
+		{
+			name:     "no summaries",
+			projects: []string{"wakalog"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			days := DaysFromSummaries(&wakatime.Summaries{Data: tt.data}, start, tt.projects)
+
+			if len(days) != len(tt.wantDates) {
+				t.Fatalf("got %d days, want %d", len(days), len(tt.wantDates))
+			}
+
+			for i, day := range days {
+
+				if date := day.Date.Format("2006-01-02"); date != tt.wantDates[i] {
+					t.Errorf("days[%d].Date = %s, want %s", i, date, tt.wantDates[i])
+				}
+
+				if day.Total != tt.wantTotal[i] {
+					t.Errorf("days[%d].Total = %s, want %s", i, day.Total, tt.wantTotal[i])
+				}
+
+				var projectsTotal time.Duration
+
+				for _, total := range day.Projects {
+					projectsTotal += total
+				}
+
+				if projectsTotal != day.Total {
+					t.Errorf("days[%d] projects add up to %s, want %s", i, projectsTotal, day.Total)
+				}
+
+			}
+
+		})
+	}
+
+}
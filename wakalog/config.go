@@ -0,0 +1,411 @@
+package wakalog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Youngtard/wakalog/calendar"
+	wakasheets "github.com/Youngtard/wakalog/sheets"
+	"github.com/Youngtard/wakalog/wakatime"
+)
+
+const (
+	configDirName  = "wakalog"
+	configFileName = "config.json"
+
+	// DefaultWorkingDays is the number of days (starting on Monday) logged for a week when not configured
+	DefaultWorkingDays = 5
+)
+
+// Config holds user preferences persisted between runs in the user config directory
+type Config struct {
+	Username      string   `json:"username,omitempty"`
+	SpreadsheetID string   `json:"spreadsheet_id,omitempty"`
+	APIURL        string   `json:"api_url,omitempty"`
+	Projects      []string `json:"projects,omitempty"`
+	WorkingDays   int      `json:"working_days,omitempty"`
+	WeekRule      string   `json:"week_rule,omitempty"`
+	LayoutFile    string   `json:"layout_file,omitempty"`
+	Timezone      string   `json:"timezone,omitempty"`
+	AuthTimeout   string   `json:"auth_timeout,omitempty"`
+	// Layout is an inline sheet layout, used when no layout file is configured
+	Layout json.RawMessage `json:"layout,omitempty"`
+
+	path string
+}
+
+type configKey struct {
+	name        string
+	description string
+	get         func(c *Config) string
+	set         func(c *Config, value string) error
+	unset       func(c *Config)
+}
+
+var configKeys = []configKey{
+	{
+		name:        "username",
+		description: "Your name as seen on the Google Sheets document",
+		get:         func(c *Config) string { return c.Username },
+		set: func(c *Config, value string) error {
+			c.Username = value
+			return nil
+		},
+		unset: func(c *Config) { c.Username = "" },
+	},
+	{
+		name:        "spreadsheet_id",
+		description: "ID of the Google Sheets document to log to",
+		get:         func(c *Config) string { return c.SpreadsheetID },
+		set: func(c *Config, value string) error {
+			c.SpreadsheetID = value
+			return nil
+		},
+		unset: func(c *Config) { c.SpreadsheetID = "" },
+	},
+	{
+		name:        "api_url",
+		description: "Base URL of a WakaTime compatible API e.g. a self-hosted Wakapi server",
+		get:         func(c *Config) string { return c.APIURL },
+		set: func(c *Config, value string) error {
+			err := wakatime.ValidateBaseURL(value)
+
+			if err != nil {
+				return err
+			}
+
+			c.APIURL = value
+			return nil
+		},
+		unset: func(c *Config) { c.APIURL = "" },
+	},
+	{
+		name:        "projects",
+		description: "Comma separated list of projects selected by default",
+		get:         func(c *Config) string { return strings.Join(c.Projects, ",") },
+		set: func(c *Config, value string) error {
+			c.Projects = SplitList(value)
+			return nil
+		},
+		unset: func(c *Config) { c.Projects = nil },
+	},
+	{
+		name:        "working_days",
+		description: "Number of days logged per week, starting on Monday (1-7)",
+		get: func(c *Config) string {
+			if c.WorkingDays == 0 {
+				return ""
+			}
+			return strconv.Itoa(c.WorkingDays)
+		},
+		set: func(c *Config, value string) error {
+			days, err := strconv.Atoi(value)
+
+			if err != nil || days < 1 || days > 7 {
+				return fmt.Errorf("working_days must be a number between 1 and 7")
+			}
+
+			c.WorkingDays = days
+			return nil
+		},
+		unset: func(c *Config) { c.WorkingDays = 0 },
+	},
+	{
+		name:        "week_rule",
+		description: "Rule deciding the month and week block of a week: first-monday, iso or majority",
+		get:         func(c *Config) string { return c.WeekRule },
+		set: func(c *Config, value string) error {
+			rule, err := calendar.ParseRule(value)
+
+			if err != nil {
+				return err
+			}
+
+			c.WeekRule = string(rule)
+			return nil
+		},
+		unset: func(c *Config) { c.WeekRule = "" },
+	},
+	{
+		name:        "layout_file",
+		description: "Path to a JSON file describing the sheet layout",
+		get:         func(c *Config) string { return c.LayoutFile },
+		set: func(c *Config, value string) error {
+			path, err := filepath.Abs(value)
+
+			if err != nil {
+				return fmt.Errorf("invalid layout_file: %w", err)
+			}
+
+			c.LayoutFile = path
+			return nil
+		},
+		unset: func(c *Config) { c.LayoutFile = "" },
+	},
+	{
+		name:        "timezone",
+		description: "IANA timezone of your days e.g. Africa/Lagos (default system timezone)",
+		get:         func(c *Config) string { return c.Timezone },
+		set: func(c *Config, value string) error {
+			_, err := time.LoadLocation(value)
+
+			if err != nil || value == "" {
+				return fmt.Errorf("unknown timezone %q: expected an IANA timezone e.g. Africa/Lagos", value)
+			}
+
+			c.Timezone = value
+			return nil
+		},
+		unset: func(c *Config) { c.Timezone = "" },
+	},
+	{
+		name:        "auth_timeout",
+		description: "How long to wait for Google authorization in the browser e.g. 10m (default 5m)",
+		get:         func(c *Config) string { return c.AuthTimeout },
+		set: func(c *Config, value string) error {
+			timeout, err := time.ParseDuration(value)
+
+			if err != nil || timeout <= 0 {
+				return fmt.Errorf("auth_timeout must be a positive duration e.g. 10m")
+			}
+
+			c.AuthTimeout = value
+			return nil
+		},
+		unset: func(c *Config) { c.AuthTimeout = "" },
+	},
+}
+
+// ConfigDir returns the wakalog directory within the user config directory (e.g. $XDG_CONFIG_HOME/wakalog)
+func ConfigDir() (string, error) {
+
+	dir, err := os.UserConfigDir()
+
+	if err != nil {
+		return "", fmt.Errorf("error locating user config directory: %w", err)
+	}
+
+	return filepath.Join(dir, configDirName), nil
+
+}
+
+// LoadConfig reads the config file. A missing file results in an empty config.
+func LoadConfig() (*Config, error) {
+
+	dir, err := ConfigDir()
+
+	if err != nil {
+		return nil, err
+	}
+
+	config := &Config{path: filepath.Join(dir, configFileName)}
+
+	data, err := os.ReadFile(config.path)
+
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return config, nil
+		}
+		return nil, fmt.Errorf("error reading config file: %w", err)
+	}
+
+	err = json.Unmarshal(data, config)
+
+	if err != nil {
+		return nil, fmt.Errorf("error parsing config file %s: %w", config.path, err)
+	}
+
+	return config, nil
+
+}
+
+// Save writes the config to the config file, creating the config directory if needed
+func (c *Config) Save() error {
+
+	if c.path == "" {
+		dir, err := ConfigDir()
+
+		if err != nil {
+			return err
+		}
+
+		c.path = filepath.Join(dir, configFileName)
+	}
+
+	err := os.MkdirAll(filepath.Dir(c.path), 0700)
+
+	if err != nil {
+		return fmt.Errorf("error creating config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(c, "", "  ")
+
+	if err != nil {
+		return fmt.Errorf("error encoding config: %w", err)
+	}
+
+	err = os.WriteFile(c.path, append(data, '\n'), 0600)
+
+	if err != nil {
+		return fmt.Errorf("error writing config file: %w", err)
+	}
+
+	return nil
+
+}
+
+// Path returns the location of the config file
+func (c *Config) Path() string {
+	return c.path
+}
+
+// Get returns the value of key as a string. Unset keys return an empty string.
+func (c *Config) Get(key string) (string, error) {
+
+	k, err := lookupConfigKey(key)
+
+	if err != nil {
+		return "", err
+	}
+
+	return k.get(c), nil
+
+}
+
+// Set parses and stores value for key. The config is not saved.
+func (c *Config) Set(key, value string) error {
+
+	k, err := lookupConfigKey(key)
+
+	if err != nil {
+		return err
+	}
+
+	return k.set(c, strings.TrimSpace(value))
+
+}
+
+// Unset clears the value of key. The config is not saved.
+func (c *Config) Unset(key string) error {
+
+	k, err := lookupConfigKey(key)
+
+	if err != nil {
+		return err
+	}
+
+	k.unset(c)
+
+	return nil
+
+}
+
+// WeekLength returns the configured number of working days, or DefaultWorkingDays if unset
+func (c *Config) WeekLength() int {
+
+	if c.WorkingDays == 0 {
+		return DefaultWorkingDays
+	}
+
+	return c.WorkingDays
+
+}
+
+// AuthorizationTimeout returns how long to wait for Google authorization in the browser, defaulting to wakasheets.DefaultAuthorizationTimeout
+func (c *Config) AuthorizationTimeout() time.Duration {
+
+	timeout, err := time.ParseDuration(c.AuthTimeout)
+
+	if err != nil || timeout <= 0 {
+		return wakasheets.DefaultAuthorizationTimeout
+	}
+
+	return timeout
+
+}
+
+// Rule returns the configured week rule, or calendar.DefaultRule if unset
+func (c *Config) Rule() (calendar.Rule, error) {
+	return calendar.ParseRule(c.WeekRule)
+}
+
+// SheetLayout returns the sheet layout from the layout file, falling back to the inline layout then the default layout.
+// Fields omitted in a layout take their value from the default layout.
+func (c *Config) SheetLayout() (*wakasheets.Layout, error) {
+
+	if c.LayoutFile != "" {
+		return wakasheets.LoadLayout(c.LayoutFile)
+	}
+
+	layout := wakasheets.DefaultLayout()
+
+	if len(c.Layout) > 0 {
+
+		err := json.Unmarshal(c.Layout, layout)
+
+		if err != nil {
+			return nil, fmt.Errorf("error parsing layout in config file: %w", err)
+		}
+
+	}
+
+	return layout, nil
+
+}
+
+// ConfigOption describes a supported config key
+type ConfigOption struct {
+	Name        string
+	Description string
+}
+
+// ConfigOptions returns the supported config keys in display order
+func ConfigOptions() []ConfigOption {
+
+	options := make([]ConfigOption, 0, len(configKeys))
+
+	for _, k := range configKeys {
+		options = append(options, ConfigOption{Name: k.name, Description: k.description})
+	}
+
+	return options
+
+}
+
+func lookupConfigKey(key string) (configKey, error) {
+
+	i := slices.IndexFunc(configKeys, func(k configKey) bool { return k.name == key })
+
+	if i < 0 {
+		return configKey{}, fmt.Errorf("%w: %s", ErrUnknownConfigKey, key)
+	}
+
+	return configKeys[i], nil
+
+}
+
+// SplitList splits a comma separated value into its trimmed, non-empty elements
+func SplitList(value string) []string {
+
+	var list []string
+
+	for _, v := range strings.Split(value, ",") {
+
+		v = strings.TrimSpace(v)
+
+		if v != "" {
+			list = append(list, v)
+		}
+
+	}
+
+	return list
+
+}
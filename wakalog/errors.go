@@ -1,6 +1,37 @@
 package wakalog
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	wakasheets "github.com/Youngtard/wakalog/sheets"
+	"github.com/Youngtard/wakalog/wakatime"
+	"github.com/charmbracelet/huh"
+)
+
+// Exit codes are stable so scripts can rely on them
+const (
+	ExitOK = 0
+	// ExitError is an error not covered by other exit codes
+	ExitError = 1
+	// ExitUsage is an invalid flag, argument or config value
+	ExitUsage = 2
+	// ExitAuthRequired is a missing, invalid or revoked WakaTime API Key or Google authorization
+	ExitAuthRequired = 3
+	// ExitRateLimited is a rate limit of WakaTime or Google Sheets
+	ExitRateLimited = 4
+	// ExitUpstreamUnavailable is a server error or timeout of WakaTime or Google Sheets
+	ExitUpstreamUnavailable = 5
+	// ExitSheetNotFound is a missing spreadsheet or month tab
+	ExitSheetNotFound = 6
+	// ExitNameNotFound is the user's name missing on the sheet
+	ExitNameNotFound = 7
+	// ExitNothingToLog is a period without activity, or already logged
+	ExitNothingToLog = 8
+	// ExitCancelled is a prompt or confirmation cancelled by the user, or an interrupt
+	ExitCancelled = 130
+)
 
 var ErrGeneric = errors.New("an error occurred")
 
@@ -9,6 +40,15 @@ var ErrWakaTimeAPIKeyNotFound = errors.New("wakatime API key not found")
 
 var ErrSheetsTokenNotFound = errors.New("sheets token not found")
 
+// ErrUnknownConfigKey is returned when getting or setting a key that is not a supported config option
+var ErrUnknownConfigKey = errors.New("unknown config key")
+
+// ErrNothingToLog is returned when no period was logged as there was no activity, or every period was already logged
+var ErrNothingToLog = errors.New("nothing to log")
+
+// ErrCancelled is returned when the user declines a confirmation. Aborted prompts and interrupts are cancellations too, see ExitCode.
+var ErrCancelled = errors.New("cancelled")
+
 type FlagError struct {
 	Err error
 }
@@ -19,6 +59,10 @@ func (fe *FlagError) Error() string {
 
 }
 
+func (fe *FlagError) Unwrap() error {
+	return fe.Err
+}
+
 type AuthError struct {
 	Err error
 }
@@ -28,3 +72,80 @@ func (ae *AuthError) Error() string {
 	return ae.Err.Error()
 
 }
+
+func (ae *AuthError) Unwrap() error {
+	return ae.Err
+}
+
+// NameNotFoundError is returned when the user's name is not found on the sheet
+type NameNotFoundError struct {
+	Err error
+}
+
+func (ne *NameNotFoundError) Error() string {
+
+	return ne.Err.Error()
+
+}
+
+func (ne *NameNotFoundError) Unwrap() error {
+	return ne.Err
+}
+
+// ExitCode returns the exit code of err, ExitOK if nil
+func ExitCode(err error) int {
+
+	var wakatimeError *wakatime.WakaTimeError
+	var sheetsError *wakasheets.SheetsError
+	var nameNotFoundError *NameNotFoundError
+	var authError *AuthError
+	var flagError *FlagError
+
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, ErrCancelled) || errors.Is(err, huh.ErrUserAborted) || errors.Is(err, context.Canceled):
+		return ExitCancelled
+	case errors.As(err, &wakatimeError):
+		return statusExitCode(wakatimeError.StatusCode)
+	case errors.As(err, &sheetsError):
+
+		if sheetsError.StatusCode == http.StatusNotFound {
+			return ExitSheetNotFound
+		}
+
+		return statusExitCode(sheetsError.StatusCode)
+
+	case errors.Is(err, wakasheets.ErrTabNotFound):
+		return ExitSheetNotFound
+	case errors.As(err, &nameNotFoundError):
+		return ExitNameNotFound
+	case errors.Is(err, ErrNothingToLog):
+		return ExitNothingToLog
+	case errors.As(err, &authError) || errors.Is(err, ErrWakaTimeAPIKeyNotFound) || errors.Is(err, wakasheets.ErrAuthorizationRequired):
+		return ExitAuthRequired
+	case errors.Is(err, context.DeadlineExceeded):
+		return ExitUpstreamUnavailable
+	case errors.As(err, &flagError):
+		return ExitUsage
+	default:
+		return ExitError
+	}
+
+}
+
+// statusExitCode returns the exit code of an error response of WakaTime or Google Sheets
+func statusExitCode(statusCode int) int {
+
+	switch {
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		return ExitAuthRequired
+	case statusCode == http.StatusTooManyRequests:
+		return ExitRateLimited
+	case statusCode >= 500:
+		return ExitUpstreamUnavailable
+	default:
+		return ExitError
+	}
+
+}
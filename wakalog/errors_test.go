@@ -0,0 +1,76 @@
+package wakalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	wakasheets "github.com/Youngtard/wakalog/sheets"
+	"github.com/Youngtard/wakalog/wakatime"
+	"github.com/charmbracelet/huh"
+	"github.com/spf13/cobra"
+)
+
+func TestExitCode(t *testing.T) {
+
+	flagGroupError := func() error {
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("wakatime", false, "")
+		cmd.Flags().Bool("google", false, "")
+		cmd.MarkFlagsMutuallyExclusive("wakatime", "google")
+		cmd.ParseFlags([]string{"--wakatime", "--google"})
+		return &FlagError{Err: cmd.ValidateFlagGroups()}
+	}
+
+	sheetsError := func(statusCode int) error {
+		return fmt.Errorf("error retrieving spreadsheet: %w", &wakasheets.SheetsError{StatusCode: statusCode, SpreadsheetID: "abc"})
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "nil", err: nil, want: ExitOK},
+		{name: "generic", err: errors.New("boom"), want: ExitError},
+		{name: "flag error", err: &FlagError{Err: errors.New("invalid --week")}, want: ExitUsage},
+		{name: "wrapped flag error", err: fmt.Errorf("error parsing flags: %w", &FlagError{Err: errors.New("invalid --week")}), want: ExitUsage},
+		{name: "args error", err: &FlagError{Err: cobra.ExactArgs(1)(&cobra.Command{}, nil)}, want: ExitUsage},
+		{name: "flag group error", err: flagGroupError(), want: ExitUsage},
+		{name: "auth error", err: &AuthError{Err: errors.New("not authorized")}, want: ExitAuthRequired},
+		{name: "auth error wrapping a WakaTime error", err: &AuthError{Err: fmt.Errorf("error validating: %w", &wakatime.WakaTimeError{StatusCode: http.StatusUnauthorized})}, want: ExitAuthRequired},
+		{name: "auth error wrapping a WakaTime server error", err: &AuthError{Err: &wakatime.WakaTimeError{StatusCode: http.StatusBadGateway}}, want: ExitUpstreamUnavailable},
+		{name: "api key not found", err: fmt.Errorf("error: %w", ErrWakaTimeAPIKeyNotFound), want: ExitAuthRequired},
+		{name: "google authorization required", err: fmt.Errorf("error: %w", wakasheets.ErrAuthorizationRequired), want: ExitAuthRequired},
+		{name: "WakaTime unauthorized", err: &wakatime.WakaTimeError{StatusCode: http.StatusUnauthorized}, want: ExitAuthRequired},
+		{name: "WakaTime rate limited", err: fmt.Errorf("error getting summaries: %w", &wakatime.WakaTimeError{StatusCode: http.StatusTooManyRequests}), want: ExitRateLimited},
+		{name: "WakaTime server error", err: &wakatime.WakaTimeError{StatusCode: http.StatusServiceUnavailable}, want: ExitUpstreamUnavailable},
+		{name: "WakaTime bad request", err: &wakatime.WakaTimeError{StatusCode: http.StatusBadRequest}, want: ExitError},
+		{name: "sheets not found", err: sheetsError(http.StatusNotFound), want: ExitSheetNotFound},
+		{name: "sheets forbidden", err: sheetsError(http.StatusForbidden), want: ExitAuthRequired},
+		{name: "sheets rate limited", err: sheetsError(http.StatusTooManyRequests), want: ExitRateLimited},
+		{name: "sheets server error", err: sheetsError(http.StatusInternalServerError), want: ExitUpstreamUnavailable},
+		{name: "sheets bad request", err: sheetsError(http.StatusBadRequest), want: ExitError},
+		{name: "tab not found", err: fmt.Errorf("error finding tab: %w", wakasheets.ErrTabNotFound), want: ExitSheetNotFound},
+		{name: "name not found", err: &NameNotFoundError{Err: errors.New("jane not found")}, want: ExitNameNotFound},
+		{name: "nothing to log", err: ErrNothingToLog, want: ExitNothingToLog},
+		{name: "cancelled", err: ErrCancelled, want: ExitCancelled},
+		{name: "prompt aborted", err: fmt.Errorf("error prompting: %w", huh.ErrUserAborted), want: ExitCancelled},
+		{name: "context cancelled", err: fmt.Errorf("error getting summaries: %w", context.Canceled), want: ExitCancelled},
+		{name: "deadline exceeded", err: fmt.Errorf("error getting summaries: %w", context.DeadlineExceeded), want: ExitUpstreamUnavailable},
+		{name: "auth error wrapping deadline exceeded", err: &AuthError{Err: fmt.Errorf("authorization timed out: %w", context.DeadlineExceeded)}, want: ExitAuthRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+
+		})
+	}
+
+}
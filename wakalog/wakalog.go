@@ -3,9 +3,13 @@ package wakalog
 import (
 	"context"
 	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
-
-	"encoding/base64"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Youngtard/wakalog/httpclient"
 	"github.com/Youngtard/wakalog/wakatime"
@@ -13,37 +17,232 @@ import (
 	"google.golang.org/api/sheets/v4"
 )
 
+// DebugEnv is the environment variable enabling debug logging e.g. WAKALOG_DEBUG=1
+const DebugEnv = "WAKALOG_DEBUG"
+
 type Application struct {
+	Config *Config
+	// Logger logs debug messages when debugging is enabled, and discards them otherwise
+	Logger *slog.Logger
+	// Trace receives HTTP traces as JSON lines when a trace file is set
+	Trace io.Writer
+	// WakaTimeURL is the base URL of the WakaTime compatible API
+	WakaTimeURL string
+	// Timezone is the IANA timezone days are computed in, empty when the system timezone can't be named
+	Timezone string
+	// Location is the location of Timezone, or the system location
+	Location *time.Location
+	// Output is the format of command results: table, json or yaml
+	Output   string
 	WakaTime *wakatime.Client
 	// TODO have a wrapper? conflicting with project sheets package
 	Sheets *sheets.Service
+
+	traceFile *os.File
+	// now returns the current time, time.Now if nil
+	now func() time.Time
 }
 
 func NewApplication(context context.Context) *Application {
 
-	app := &Application{}
+	app := &Application{
+		Logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
 
 	return app
 
 }
 
-func (app *Application) InitializeWakaTime(apiKey string) {
+// ConfigureLogging enables debug logging to stderr when debug is set or the WAKALOG_DEBUG environment variable is truthy,
+// and HTTP tracing to traceFile when not empty
+func (app *Application) ConfigureLogging(debug bool, traceFile string) error {
 
-	// TODO check if apiKey is not empty
-	// TODO nil checks?
+	if !debug {
+		debug, _ = strconv.ParseBool(os.Getenv(DebugEnv))
+	}
+
+	if debug {
+		app.Logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	}
+
+	if traceFile != "" {
+
+		f, err := os.OpenFile(traceFile, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+
+		if err != nil {
+			return &FlagError{Err: fmt.Errorf("error opening trace file: %w", err)}
+		}
 
-	encodedKey := base64.StdEncoding.EncodeToString([]byte(apiKey))
+		app.traceFile = f
+		app.Trace = f
 
-	hc := httpclient.NewClient(nil).WithBasicAuth(encodedKey)
+	}
+
+	return nil
 
-	wc := wakatime.NewClient(hc)
+}
+
+// Close releases resources held by the application i.e. the trace file
+func (app *Application) Close() error {
+
+	if app.traceFile == nil {
+		return nil
+	}
 
-	app.WakaTime = wc
+	return app.traceFile.Close()
+
+}
+
+// LoadConfig loads the user config into the application
+func (app *Application) LoadConfig() error {
+
+	config, err := LoadConfig()
+
+	if err != nil {
+		return fmt.Errorf("error loading config: %w", err)
+	}
+
+	app.Config = config
+
+	return nil
+
+}
+
+// ResolveWakaTimeURL sets the WakaTime base URL from flagValue, falling back to the WAKATIME_API_URL environment variable,
+// config, then the WakaTime API
+func (app *Application) ResolveWakaTimeURL(flagValue string) error {
+
+	baseURL := flagValue
+
+	if baseURL == "" {
+		baseURL = strings.TrimSpace(os.Getenv(wakatime.BaseURLEnv))
+	}
+
+	if baseURL == "" && app.Config != nil {
+		baseURL = app.Config.APIURL
+	}
+
+	if baseURL == "" {
+		baseURL = wakatime.DefaultBaseURL
+	}
+
+	err := wakatime.ValidateBaseURL(baseURL)
+
+	if err != nil {
+		return &FlagError{Err: err}
+	}
+
+	app.WakaTimeURL = baseURL
+
+	return nil
+
+}
+
+// localtimePath is the symlink to the system timezone's zoneinfo file
+const localtimePath = "/etc/localtime"
+
+// ResolveTimezone sets the timezone from flagValue, falling back to config, then the system timezone
+func (app *Application) ResolveTimezone(flagValue string) error {
+
+	timezone := flagValue
+
+	if timezone == "" && app.Config != nil {
+		timezone = app.Config.Timezone
+	}
+
+	if timezone == "" {
+		timezone = systemTimezone(os.Getenv("TZ"), localtimePath)
+	}
+
+	/// Without a name, days are computed locally but WakaTime uses the account's timezone
+	if timezone == "" {
+		app.Timezone = ""
+		app.Location = time.Local
+		return nil
+	}
+
+	location, err := time.LoadLocation(timezone)
+
+	if err != nil {
+		return &FlagError{Err: fmt.Errorf("unknown timezone %q: expected an IANA timezone e.g. Africa/Lagos", timezone)}
+	}
+
+	app.Timezone = timezone
+	app.Location = location
+
+	return nil
+
+}
+
+// systemTimezone returns the IANA name of the system timezone from the TZ environment variable tz,
+// or the zoneinfo file localtime links to, empty if neither names a known timezone
+func systemTimezone(tz string, localtime string) string {
+
+	name := strings.TrimPrefix(tz, ":")
+
+	if name == "" {
+
+		target, err := os.Readlink(localtime)
+
+		if err != nil {
+			return ""
+		}
+
+		name = target
+
+	}
+
+	/// e.g. /usr/share/zoneinfo/Europe/London
+	if _, after, found := strings.Cut(name, "zoneinfo/"); found {
+		name = after
+	}
+
+	if name == "" || name == "Local" || strings.HasPrefix(name, "/") {
+		return ""
+	}
+
+	if _, err := time.LoadLocation(name); err != nil {
+		return ""
+	}
+
+	return name
+
+}
+
+// Now returns the current time in the resolved timezone
+func (app *Application) Now() time.Time {
+
+	now := time.Now
+
+	if app.now != nil {
+		now = app.now
+	}
+
+	if app.Location == nil {
+		return now()
+	}
+
+	return now().In(app.Location)
+
+}
+
+func (app *Application) InitializeWakaTime(apiKey string) {
+
+	// TODO check if apiKey is not empty
+	// TODO nil checks?
+
+	app.WakaTime = wakatime.NewClientWithAPIKey(apiKey, wakatime.WithBaseURL(app.WakaTimeURL), wakatime.WithLogger(app.Logger, app.Trace), wakatime.WithCache(httpclient.NewMemoryCache()))
 
 }
 
 func (app *Application) InitializeSheets(context context.Context, client *http.Client) error {
 
+	/// The oauth2 transport sets the Authorization header itself, so it's absent rather than redacted in traces
+	client = &http.Client{
+		Transport: &httpclient.LoggingTransport{Transport: client.Transport, Logger: app.Logger, Trace: app.Trace},
+		Timeout:   client.Timeout,
+	}
+
 	srv, err := sheets.NewService(context, option.WithHTTPClient(client))
 
 	if err != nil {
@@ -0,0 +1,142 @@
+package wakalog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSystemTimezone(t *testing.T) {
+
+	dir := t.TempDir()
+
+	link := func(name, target string) string {
+
+		path := filepath.Join(dir, name)
+
+		if err := os.Symlink(target, path); err != nil {
+			t.Fatal(err)
+		}
+
+		return path
+
+	}
+
+	london := link("london", "/usr/share/zoneinfo/Europe/London")
+	macOS := link("macos", "/var/db/timezone/zoneinfo/America/New_York")
+	unknown := link("unknown", "/usr/share/zoneinfo/Nowhere/Special")
+
+	file := filepath.Join(dir, "file")
+
+	if err := os.WriteFile(file, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		tz        string
+		localtime string
+		want      string
+	}{
+		{name: "TZ", tz: "Africa/Lagos", localtime: london, want: "Africa/Lagos"},
+		{name: "TZ with colon", tz: ":Africa/Lagos", want: "Africa/Lagos"},
+		{name: "TZ as a zoneinfo path", tz: "/usr/share/zoneinfo/Asia/Tokyo", want: "Asia/Tokyo"},
+		{name: "unknown TZ", tz: "Nowhere/Special", localtime: london, want: ""},
+		{name: "localtime link", localtime: london, want: "Europe/London"},
+		{name: "macOS localtime link", localtime: macOS, want: "America/New_York"},
+		{name: "unknown localtime link", localtime: unknown, want: ""},
+		{name: "localtime not a link", localtime: file, want: ""},
+		{name: "no localtime", localtime: filepath.Join(dir, "missing"), want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			if got := systemTimezone(tt.tz, tt.localtime); got != tt.want {
+				t.Errorf("systemTimezone(%q, %q) = %q, want %q", tt.tz, tt.localtime, got, tt.want)
+			}
+
+		})
+	}
+
+}
+
+func TestResolveTimezone(t *testing.T) {
+
+	t.Setenv("TZ", "Asia/Tokyo")
+
+	tests := []struct {
+		name    string
+		flag    string
+		config  string
+		want    string
+		wantErr bool
+	}{
+		{name: "flag", flag: "Africa/Lagos", config: "Europe/London", want: "Africa/Lagos"},
+		{name: "config", config: "Europe/London", want: "Europe/London"},
+		{name: "system", want: "Asia/Tokyo"},
+		{name: "unknown", flag: "Nowhere/Special", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			app := &Application{Config: &Config{Timezone: tt.config}}
+
+			err := app.ResolveTimezone(tt.flag)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ResolveTimezone(%q) = nil, want an error", tt.flag)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if app.Timezone != tt.want || app.Location.String() != tt.want {
+				t.Errorf("Timezone = %q, Location = %s, want %s", app.Timezone, app.Location, tt.want)
+			}
+
+		})
+	}
+
+}
+
+func TestNowAcrossDST(t *testing.T) {
+
+	london, err := time.LoadLocation("Europe/London")
+
+	if err != nil {
+		t.Skip("Europe/London not available:", err)
+	}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want string
+	}{
+		{name: "before clocks go forward", now: time.Date(2026, time.March, 29, 0, 59, 0, 0, time.UTC), want: "2026-03-29 00:59 GMT"},
+		{name: "after clocks go forward", now: time.Date(2026, time.March, 29, 1, 0, 0, 0, time.UTC), want: "2026-03-29 02:00 BST"},
+		{name: "saturday in BST is friday in UTC", now: time.Date(2026, time.April, 3, 23, 30, 0, 0, time.UTC), want: "2026-04-04 00:30 BST"},
+		{name: "before clocks go back", now: time.Date(2026, time.October, 25, 0, 59, 0, 0, time.UTC), want: "2026-10-25 01:59 BST"},
+		{name: "after clocks go back", now: time.Date(2026, time.October, 25, 1, 0, 0, 0, time.UTC), want: "2026-10-25 01:00 GMT"},
+		{name: "midnight in GMT", now: time.Date(2026, time.October, 31, 0, 0, 0, 0, time.UTC), want: "2026-10-31 00:00 GMT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			app := &Application{Location: london, now: func() time.Time { return tt.now }}
+
+			if got := app.Now().Format("2006-01-02 15:04 MST"); got != tt.want {
+				t.Errorf("Now() = %s, want %s", got, tt.want)
+			}
+
+		})
+	}
+
+}
@@ -5,45 +5,231 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Youngtard/wakalog/httpclient"
 )
 
-func (r *Client) GetSummaries(ctx context.Context, startTime, endTime time.Time) (*Summaries, error) {
+// SummariesOptions filters summaries
+type SummariesOptions struct {
+	// Project only includes activity of this project, scoping languages, editors, categories etc. to it
+	Project string
+	// Branches only includes activity on these branches, requires Project
+	Branches []string
+	// Timezone is the timezone of the days e.g. Africa/Lagos, defaulting to the user's WakaTime timezone
+	Timezone string
+	// Timeout is the keystroke timeout in minutes joining heartbeats into durations, defaulting to the user's WakaTime timeout
+	Timeout int
+	// WritesOnly only includes heartbeats of file writes
+	WritesOnly bool
+}
+
+func (r *Client) GetSummaries(ctx context.Context, startTime, endTime time.Time, opts *SummariesOptions) (*Summaries, error) {
+
+	summaries := new(Summaries)
+
+	/// Dates are calendar days in opts.Timezone (or the user's WakaTime timezone), whatever the location of startTime and endTime
+	values := url.Values{}
+	values.Add("start", startTime.Format("2006-01-02"))
+	values.Add("end", endTime.Format("2006-01-02"))
+
+	if opts != nil {
+
+		if opts.Project != "" {
+			values.Add("project", opts.Project)
+		}
+
+		if len(opts.Branches) > 0 {
+			values.Add("branches", strings.Join(opts.Branches, ","))
+		}
+
+		if opts.Timezone != "" {
+			values.Add("timezone", opts.Timezone)
+		}
+
+		if opts.Timeout > 0 {
+			values.Add("timeout", strconv.Itoa(opts.Timeout))
+		}
 
-	startYear := startTime.Year()
-	startMonth := startTime.Month()
-	startDay := startTime.Day()
+		if opts.WritesOnly {
+			values.Add("writes_only", "true")
+		}
 
-	endYear := endTime.Year()
-	endMonth := endTime.Month()
-	endDay := endTime.Day()
+	}
 
-	u, err := httpclient.ParseURL(baseURL, "/users/current/summaries")
+	err := r.get(ctx, "/users/current/summaries", values, summaries)
 
 	if err != nil {
-		return nil, fmt.Errorf("error parsing url: %w", err)
+		return nil, err
 	}
 
-	summaries := new(Summaries)
+	return summaries, nil
+
+}
+
+// GetCurrentUser returns the user owning the API Key
+func (r *Client) GetCurrentUser(ctx context.Context) (*User, error) {
+
+	currentUser := new(CurrentUser)
+
+	err := r.get(ctx, "/users/current", nil, currentUser)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &currentUser.Data, nil
+
+}
+
+// GetStats returns stats over statsRange e.g. StatsLast7Days, a year (2026) or a month (2026-09)
+func (r *Client) GetStats(ctx context.Context, statsRange string) (*Stats, error) {
+
+	stats := new(StatsResponse)
+
+	err := r.get(ctx, "/users/current/stats/"+url.PathEscape(statsRange), nil, stats)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &stats.Data, nil
+
+}
+
+// GetDurations returns the durations of coding activity on date
+func (r *Client) GetDurations(ctx context.Context, date time.Time, opts *DurationsOptions) (*Durations, error) {
+
+	durations := new(Durations)
 
 	values := url.Values{}
-	values.Add("start", fmt.Sprintf("%d-%d-%d", startYear, startMonth, startDay))
-	values.Add("end", fmt.Sprintf("%d-%d-%d", endYear, endMonth, endDay))
+	values.Add("date", date.Format("2006-01-02"))
 
-	_, err = r.httpclient.Get(ctx, u, values, summaries)
+	if opts != nil {
+
+		if opts.Project != "" {
+			values.Add("project", opts.Project)
+		}
+
+		if len(opts.Branches) > 0 {
+			values.Add("branches", strings.Join(opts.Branches, ","))
+		}
+
+		if opts.Timezone != "" {
+			values.Add("timezone", opts.Timezone)
+		}
+
+		if opts.SliceBy != "" {
+			values.Add("slice_by", opts.SliceBy)
+		}
+
+	}
+
+	err := r.get(ctx, "/users/current/durations", values, durations)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return durations, nil
+
+}
+
+// GetProjects returns the user's projects, optionally filtered by a search query
+func (r *Client) GetProjects(ctx context.Context, query string) (*Projects, error) {
+
+	projects := new(Projects)
+
+	values := url.Values{}
+
+	if query != "" {
+		values.Add("q", query)
+	}
+
+	err := r.get(ctx, "/users/current/projects", values, projects)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+
+}
+
+// GetGoals returns the user's goals
+func (r *Client) GetGoals(ctx context.Context) (*Goals, error) {
+
+	goals := new(Goals)
+
+	err := r.get(ctx, "/users/current/goals", nil, goals)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return goals, nil
+
+}
+
+// GetAllTimeSinceToday returns the total time logged since account creation, optionally for a single project
+func (r *Client) GetAllTimeSinceToday(ctx context.Context, project string) (*AllTimeSinceToday, error) {
+
+	allTime := new(AllTimeSinceTodayResponse)
+
+	values := url.Values{}
+
+	if project != "" {
+		values.Add("project", project)
+	}
+
+	err := r.get(ctx, "/users/current/all_time_since_today", values, allTime)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &allTime.Data, nil
+
+}
+
+// GetStatusBarToday returns today's summary as shown in IDE status bars
+func (r *Client) GetStatusBarToday(ctx context.Context) (*SummariesData, error) {
+
+	statusBar := new(StatusBarResponse)
+
+	err := r.get(ctx, "/users/current/status_bar/today", nil, statusBar)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &statusBar.Data, nil
+
+}
+
+// get fetches urlPath relative to the base URL into v, converting error responses to WakaTimeError
+func (r *Client) get(ctx context.Context, urlPath string, values url.Values, v interface{}) error {
+
+	u, err := httpclient.ParseURL(r.baseURL, urlPath)
+
+	if err != nil {
+		return fmt.Errorf("error parsing url: %w", err)
+	}
+
+	_, err = r.httpclient.Get(ctx, u, values, v)
 
 	if err != nil {
 
 		var serverError *httpclient.ServerError
 
 		if errors.As(err, &serverError) {
-			return nil, handleWakaTimeError(serverError)
+			return handleWakaTimeError(serverError)
 		}
-		return nil, fmt.Errorf("error executing request: %w", err)
+		return fmt.Errorf("error executing request: %w", err)
 	}
 
-	return summaries, nil
+	return nil
 
 }
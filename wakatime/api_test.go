@@ -0,0 +1,305 @@
+package wakatime
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Youngtard/wakalog/httpclient"
+)
+
+// newFixtureClient returns a client whose requests to path are served testdata/fixture, recording the query of the last request
+func newFixtureClient(t *testing.T, path string, fixture string) (*Client, *url.Values) {
+
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("testdata", fixture))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query := new(url.Values)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		if r.URL.Path != "/api/v1"+path {
+			http.NotFound(w, r)
+			return
+		}
+
+		*query = r.URL.Query()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClientWithAPIKey("key", WithBaseURL(server.URL+"/api/v1"), WithRetryPolicy(httpclient.RetryPolicy{}))
+
+	return client, query
+
+}
+
+func TestGetSummaries(t *testing.T) {
+
+	client, query := newFixtureClient(t, "/users/current/summaries", "summaries.json")
+
+	start := time.Date(2026, time.October, 12, 0, 0, 0, 0, time.UTC)
+
+	summaries, err := client.GetSummaries(context.Background(), start, start, &SummariesOptions{Project: "wakalog", Branches: []string{"main", "dev"}, Timezone: "Africa/Lagos"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantQuery := url.Values{"start": {"2026-10-12"}, "end": {"2026-10-12"}, "project": {"wakalog"}, "branches": {"main,dev"}, "timezone": {"Africa/Lagos"}}
+
+	if query.Encode() != wantQuery.Encode() {
+		t.Errorf("query = %s, want %s", query.Encode(), wantQuery.Encode())
+	}
+
+	if len(summaries.Data) != 1 {
+		t.Fatalf("len(Data) = %d, want 1", len(summaries.Data))
+	}
+
+	day := summaries.Data[0]
+
+	if day.GrandTotal.TotalSeconds != 12345.678 || day.Range.Date != "2026-10-12" {
+		t.Errorf("day = %+v %+v", day.GrandTotal, day.Range)
+	}
+
+	if len(day.Projects) != 1 || day.Projects[0].Name != "wakalog" || day.Projects[0].Seconds != 45 {
+		t.Errorf("Projects = %+v", day.Projects)
+	}
+
+	if summaries.DailyAverage.Seconds != 12345.678 || summaries.DailyAverage.SecondsIncludingOtherLanguage != 12400.5 {
+		t.Errorf("DailyAverage = %+v", summaries.DailyAverage)
+	}
+
+}
+
+func TestGetStats(t *testing.T) {
+
+	client, _ := newFixtureClient(t, "/users/current/stats/last_7_days", "stats.json")
+
+	stats, err := client.GetStats(context.Background(), StatsLast7Days)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stats.TotalSeconds != 98765.432 || stats.DailyAverage != 14109.347 || stats.PercentCalculated != 100 {
+		t.Errorf("totals = %v %v %v", stats.TotalSeconds, stats.DailyAverage, stats.PercentCalculated)
+	}
+
+	if len(stats.Projects) != 2 || stats.Projects[1].Name != "dotfiles" || stats.Projects[1].Percent != 39.25 {
+		t.Errorf("Projects = %+v", stats.Projects)
+	}
+
+	if stats.BestDay == nil || stats.BestDay.Date != "2026-10-14" || stats.BestDay.TotalSeconds != 22345.678 {
+		t.Errorf("BestDay = %+v", stats.BestDay)
+	}
+
+	if stats.Range != StatsLast7Days || stats.Timezone != "Africa/Lagos" || stats.Timeout != 15 || !stats.IsUpToDate {
+		t.Errorf("stats = %+v", stats)
+	}
+
+	if want := time.Date(2026, time.October, 11, 0, 0, 0, 0, time.UTC); !stats.Start.Equal(want) {
+		t.Errorf("Start = %s, want %s", stats.Start, want)
+	}
+
+}
+
+func TestGetDurations(t *testing.T) {
+
+	client, query := newFixtureClient(t, "/users/current/durations", "durations.json")
+
+	date := time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+
+	durations, err := client.GetDurations(context.Background(), date, &DurationsOptions{SliceBy: "language"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if query.Get("date") != "2026-10-18" || query.Get("slice_by") != "language" {
+		t.Errorf("query = %s", query.Encode())
+	}
+
+	if len(durations.Data) != 2 {
+		t.Fatalf("len(Data) = %d, want 2", len(durations.Data))
+	}
+
+	if first := durations.Data[0]; first.Project != "wakalog" || first.Time != 1760770800.123 || first.Duration != 1834.56 || first.Color != nil {
+		t.Errorf("Data[0] = %+v", first)
+	}
+
+	if second := durations.Data[1]; second.Color == nil || *second.Color != "#ff0000" {
+		t.Errorf("Data[1].Color = %v", second.Color)
+	}
+
+	if len(durations.Branches) != 2 || durations.Timezone != "Africa/Lagos" {
+		t.Errorf("durations = %+v", durations)
+	}
+
+}
+
+func TestGetProjects(t *testing.T) {
+
+	client, query := newFixtureClient(t, "/users/current/projects", "projects.json")
+
+	projects, err := client.GetProjects(context.Background(), "waka")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if query.Get("q") != "waka" {
+		t.Errorf("q = %q, want %q", query.Get("q"), "waka")
+	}
+
+	if len(projects.Data) != 1 {
+		t.Fatalf("len(Data) = %d, want 1", len(projects.Data))
+	}
+
+	project := projects.Data[0]
+
+	if project.ID != "p1" || project.Name != "wakalog" || project.URLEncodedName != "wakalog" || project.HasPublicURL {
+		t.Errorf("project = %+v", project)
+	}
+
+	if want := time.Date(2026, time.October, 18, 3, 4, 5, 0, time.UTC); project.LastHeartbeatAt == nil || !project.LastHeartbeatAt.Equal(want) {
+		t.Errorf("LastHeartbeatAt = %v, want %s", project.LastHeartbeatAt, want)
+	}
+
+}
+
+func TestGetGoals(t *testing.T) {
+
+	client, _ := newFixtureClient(t, "/users/current/goals", "goals.json")
+
+	goals, err := client.GetGoals(context.Background())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if goals.Total != 1 || goals.TotalPages != 1 || len(goals.Data) != 1 {
+		t.Fatalf("goals = %+v", goals)
+	}
+
+	goal := goals.Data[0]
+
+	if goal.ID != "g1" || goal.Seconds != 14400 || goal.ImproveByPercent != nil || goal.ModifiedAt != nil || len(goal.IgnoreDays) != 2 {
+		t.Errorf("goal = %+v", goal)
+	}
+
+	if len(goal.ChartData) != 1 || goal.ChartData[0].ActualSeconds != 15123.45 || goal.ChartData[0].Range.Date != "2026-10-16" {
+		t.Errorf("ChartData = %+v", goal.ChartData)
+	}
+
+}
+
+func TestGetAllTimeSinceToday(t *testing.T) {
+
+	client, query := newFixtureClient(t, "/users/current/all_time_since_today", "all_time_since_today.json")
+
+	allTime, err := client.GetAllTimeSinceToday(context.Background(), "wakalog")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if query.Get("project") != "wakalog" {
+		t.Errorf("project = %q, want %q", query.Get("project"), "wakalog")
+	}
+
+	if allTime.TotalSeconds != 1234567.891 || allTime.DailyAverage != 4567.25 || allTime.PercentCalculated != 100 || !allTime.IsUpToDate {
+		t.Errorf("allTime = %+v", allTime)
+	}
+
+	if allTime.Range.StartDate != "2026-01-02" || allTime.Range.EndDate != "2026-10-18" || allTime.Range.Timezone != "Africa/Lagos" {
+		t.Errorf("Range = %+v", allTime.Range)
+	}
+
+}
+
+func TestGetStatusBarToday(t *testing.T) {
+
+	client, _ := newFixtureClient(t, "/users/current/status_bar/today", "status_bar_today.json")
+
+	today, err := client.GetStatusBarToday(context.Background())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if today.GrandTotal.TotalSeconds != 7512.345 || today.GrandTotal.Hours != 2 || today.GrandTotal.Minutes != 5 {
+		t.Errorf("GrandTotal = %+v", today.GrandTotal)
+	}
+
+	if len(today.Languages) != 1 || today.Languages[0].Name != "Go" || today.Languages[0].Percent != 93.19 {
+		t.Errorf("Languages = %+v", today.Languages)
+	}
+
+	if today.Range.Date != "2026-10-18" {
+		t.Errorf("Range.Date = %q, want %q", today.Range.Date, "2026-10-18")
+	}
+
+}
+
+func TestGetSummariesRevalidatesCache(t *testing.T) {
+
+	body, err := os.ReadFile(filepath.Join("testdata", "summaries.json"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var notModified int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"v1"`)
+		w.Write(body)
+
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClientWithAPIKey("key", WithBaseURL(server.URL+"/api/v1"), WithRetryPolicy(httpclient.RetryPolicy{}), WithCache(httpclient.NewMemoryCache()))
+
+	start := time.Date(2026, time.October, 12, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 2; i++ {
+
+		summaries, err := client.GetSummaries(context.Background(), start, start, nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(summaries.Data) != 1 || summaries.Data[0].GrandTotal.TotalSeconds != 12345.678 {
+			t.Errorf("request %d: Data = %+v", i, summaries.Data)
+		}
+
+	}
+
+	if notModified != 1 {
+		t.Errorf("got %d 304 responses, want 1", notModified)
+	}
+
+}
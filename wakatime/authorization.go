@@ -2,16 +2,46 @@ package wakatime
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
+	"time"
 
+	"github.com/Youngtard/wakalog/httpclient"
 	"github.com/charmbracelet/huh"
 	"github.com/savioxavier/termlink"
 )
 
-func Authorize(ctx context.Context) (string, error) {
+// Authorize prompts for an API Key of the API at baseURL, validating it against the current user endpoint before storing it.
+// The API Key and its user are returned.
+func Authorize(ctx context.Context, baseURL string) (string, *User, error) {
 
 	var apiKey string
+	var user *User
+
+	// Validation runs on both submit and blur, so the last result is reused for an unchanged key
+	var validatedKey string
+	var validationErr error
+
+	validate := func(str string) error {
+
+		str = strings.TrimSpace(str)
+
+		if len(str) == 0 {
+			return fmt.Errorf("API Key is required to proceed.")
+		}
+
+		if str == validatedKey {
+			return validationErr
+		}
+
+		validatedKey = str
+		user, validationErr = validateAPIKey(ctx, baseURL, str)
+
+		return validationErr
+
+	}
 
 	apiKeyUrl := "https://wakatime.com/settings/api-key"
 
@@ -19,7 +49,13 @@ func Authorize(ctx context.Context) (string, error) {
 	prompt := "Enter your WakaTime API Key to proceed."
 	var apiKeyPrompt string
 
-	if termlink.SupportsHyperlinks() {
+	isWakaTime := keyringUser(baseURL) == userName
+
+	if !isWakaTime {
+		prompt = fmt.Sprintf("Enter your API Key for %s to proceed.", baseURL)
+	}
+
+	if termlink.SupportsHyperlinks() && isWakaTime {
 		apiKeyPrompt = fmt.Sprintf("%s %s", prompt, apiKeyLink)
 	} else {
 		apiKeyPrompt = prompt
@@ -31,32 +67,56 @@ func Authorize(ctx context.Context) (string, error) {
 				Title(apiKeyPrompt).
 				Placeholder("Enter API Key...").
 				Value(&apiKey).
-				Validate(func(str string) error {
-					if len(str) == 0 {
-						return fmt.Errorf("API Key is required to proceed.")
-					}
-					return nil
-				}).WithTheme(huh.ThemeBase()),
+				Validate(validate).WithTheme(huh.ThemeBase()),
 		),
 	)
 
 	err := form.RunWithContext(ctx)
 
 	if err != nil {
-		return "", fmt.Errorf("error generating api key input: %w", err)
+		return "", nil, fmt.Errorf("error generating api key input: %w", err)
 
 	}
 
 	apiKey = strings.TrimSpace(apiKey)
 
-	err = StoreAPIKey(apiKey)
+	err = StoreAPIKey(baseURL, apiKey)
 
 	if err != nil {
 
-		return "", fmt.Errorf("error storing wakatime api key: %w", err)
+		return "", nil, fmt.Errorf("error storing wakatime api key: %w", err)
+
+	}
+
+	return apiKey, user, nil
+
+}
+
+// validationTimeout bounds validating an API Key, so the prompt doesn't hang on an unresponsive server
+const validationTimeout = 15 * time.Second
+
+func validateAPIKey(ctx context.Context, baseURL string, apiKey string) (*User, error) {
+
+	ctx, cancel := context.WithTimeout(ctx, validationTimeout)
+	defer cancel()
+
+	/// Validation runs within the prompt, so fail fast rather than backing off on server errors
+	client := NewClientWithAPIKey(apiKey, WithBaseURL(baseURL), WithRetryPolicy(httpclient.RetryPolicy{}))
+
+	user, err := client.GetCurrentUser(ctx)
+
+	if err != nil {
+
+		var wakatimeError *WakaTimeError
+
+		if errors.As(err, &wakatimeError) && (wakatimeError.StatusCode == http.StatusUnauthorized || wakatimeError.StatusCode == http.StatusForbidden) {
+			return nil, fmt.Errorf("Invalid API Key.")
+		}
+
+		return nil, fmt.Errorf("Unable to validate API Key: %s", err)
 
 	}
 
-	return apiKey, nil
+	return user, nil
 
 }
@@ -0,0 +1,36 @@
+package wakatime
+
+type Durations struct {
+	Data     []Duration `json:"data"`
+	Branches []string   `json:"branches"`
+	Start    string     `json:"start"`
+	End      string     `json:"end"`
+	Timezone string     `json:"timezone"`
+}
+
+type Duration struct {
+	Project string `json:"project"`
+	// Time is the start of the duration as a UNIX epoch timestamp
+	Time float64 `json:"time"`
+	// Duration is the length of the duration in seconds
+	Duration float64 `json:"duration"`
+	// The attribute durations are sliced by is set instead of project when slicing by other than project
+	Language     string   `json:"language,omitempty"`
+	Entity       string   `json:"entity,omitempty"`
+	Category     string   `json:"category,omitempty"`
+	Branch       string   `json:"branch,omitempty"`
+	Dependencies []string `json:"dependencies,omitempty"`
+	Color        *string  `json:"color"`
+}
+
+// DurationsOptions filters durations
+type DurationsOptions struct {
+	// Project only includes durations of this project
+	Project string
+	// Branches only includes durations of these branches, requires Project
+	Branches []string
+	// Timezone is the timezone of the day e.g. Africa/Lagos, defaulting to the user's WakaTime timezone
+	Timezone string
+	// SliceBy groups durations by an attribute other than project e.g. language, entity, category or dependencies
+	SliceBy string
+}
@@ -2,7 +2,6 @@ package wakatime
 
 import (
 	"encoding/json"
-	"fmt"
 	"io"
 	"net/http"
 	"strings"
@@ -37,21 +36,25 @@ func (we *WakaTimeError) Error() string {
 
 }
 
+// handleWakaTimeError returns a WakaTimeError with the status code of serverError, and its message when the body has one
 func handleWakaTimeError(serverError *httpclient.ServerError) error {
+
+	defer serverError.Body.Close()
+
+	wakatimeError := &WakaTimeError{StatusCode: serverError.StatusCode}
+
+	/// Proxies and gateways may respond with HTML or an empty body, keeping the status code is what matters
 	body, err := io.ReadAll(serverError.Body)
 
 	if err != nil {
-		return fmt.Errorf("error reading response body: %w", err)
+		return wakatimeError
 	}
 
-	wakatimeError := &WakaTimeError{StatusCode: serverError.StatusCode}
-
-	if body != nil {
-		err = json.Unmarshal(body, wakatimeError)
+	var message WakaTimeError
 
-		if err != nil {
-			return fmt.Errorf("error unmarshalling response body: %w", err)
-		}
+	if json.Unmarshal(body, &message) == nil {
+		wakatimeError.ErrorMessage = message.ErrorMessage
+		wakatimeError.Errors = message.Errors
 	}
 
 	return wakatimeError
@@ -0,0 +1,65 @@
+package wakatime
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Youngtard/wakalog/httpclient"
+)
+
+// trackedBody records whether it was closed
+type trackedBody struct {
+	io.Reader
+	closed bool
+}
+
+func (b *trackedBody) Close() error {
+
+	b.closed = true
+
+	return nil
+
+}
+
+func TestHandleWakaTimeError(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		statusCode  int
+		body        string
+		wantMessage string
+	}{
+		{name: "error message", statusCode: http.StatusUnauthorized, body: `{"error":"Unauthorized"}`, wantMessage: "Unauthorized"},
+		{name: "errors", statusCode: http.StatusBadRequest, body: `{"errors":["invalid start","invalid end"]}`, wantMessage: "invalid start,invalid end"},
+		{name: "html", statusCode: http.StatusBadGateway, body: `<html><body>502 Bad Gateway</body></html>`, wantMessage: "an error occurred"},
+		{name: "empty body", statusCode: http.StatusServiceUnavailable, wantMessage: "an error occurred"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			body := &trackedBody{Reader: strings.NewReader(tt.body)}
+
+			err := handleWakaTimeError(&httpclient.ServerError{Body: body, StatusCode: tt.statusCode})
+
+			var wakatimeError *WakaTimeError
+
+			if !errors.As(err, &wakatimeError) || wakatimeError.StatusCode != tt.statusCode {
+				t.Fatalf("handleWakaTimeError() = %#v, want a WakaTimeError with status code %d", err, tt.statusCode)
+			}
+
+			if err.Error() != tt.wantMessage {
+				t.Errorf("Error() = %q, want %q", err.Error(), tt.wantMessage)
+			}
+
+			if !body.closed {
+				t.Error("response body not closed")
+			}
+
+		})
+	}
+
+}
@@ -0,0 +1,46 @@
+package wakatime
+
+import "time"
+
+type Goals struct {
+	Data       []Goal `json:"data"`
+	Total      int    `json:"total"`
+	TotalPages int    `json:"total_pages"`
+}
+
+type Goal struct {
+	ID               string          `json:"id"`
+	Title            string          `json:"title"`
+	Status           string          `json:"status"`
+	IsEnabled        bool            `json:"is_enabled"`
+	IsInverse        bool            `json:"is_inverse"`
+	IsSnoozed        bool            `json:"is_snoozed"`
+	IsTweeting       bool            `json:"is_tweeting"`
+	IgnoreDays       []string        `json:"ignore_days"`
+	IgnoreZeroDays   bool            `json:"ignore_zero_days"`
+	ImproveByPercent *float64        `json:"improve_by_percent"`
+	Delta            string          `json:"delta"`
+	Seconds          float64         `json:"seconds"`
+	RangeText        string          `json:"range_text"`
+	AverageStatus    string          `json:"average_status"`
+	CumulativeStatus string          `json:"cumulative_status"`
+	CustomTitle      *string         `json:"custom_title"`
+	Languages        []string        `json:"languages"`
+	Projects         []string        `json:"projects"`
+	Editors          []string        `json:"editors"`
+	Categories       []string        `json:"categories"`
+	ChartData        []GoalChartData `json:"chart_data"`
+	SnoozeUntil      *time.Time      `json:"snooze_until"`
+	CreatedAt        *time.Time      `json:"created_at"`
+	ModifiedAt       *time.Time      `json:"modified_at"`
+}
+
+type GoalChartData struct {
+	ActualSeconds     float64      `json:"actual_seconds"`
+	ActualSecondsText string       `json:"actual_seconds_text"`
+	GoalSeconds       float64      `json:"goal_seconds"`
+	GoalSecondsText   string       `json:"goal_seconds_text"`
+	Range             SummaryRange `json:"range"`
+	RangeStatus       string       `json:"range_status"`
+	RangeStatusReason string       `json:"range_status_reason"`
+}
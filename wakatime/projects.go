@@ -0,0 +1,22 @@
+package wakatime
+
+import "time"
+
+type Projects struct {
+	Data []UserProject `json:"data"`
+}
+
+// UserProject is a project the user has sent coding activity for
+type UserProject struct {
+	ID                           string      `json:"id"`
+	Name                         string      `json:"name"`
+	Repository                   interface{} `json:"repository"`
+	Badge                        interface{} `json:"badge"`
+	Color                        *string     `json:"color"`
+	HasPublicURL                 bool        `json:"has_public_url"`
+	HumanReadableLastHeartbeatAt string      `json:"human_readable_last_heartbeat_at"`
+	LastHeartbeatAt              *time.Time  `json:"last_heartbeat_at"`
+	URL                          string      `json:"url"`
+	URLEncodedName               string      `json:"urlencoded_name"`
+	CreatedAt                    *time.Time  `json:"created_at"`
+}
@@ -0,0 +1,93 @@
+package wakatime
+
+import "time"
+
+// Stats ranges
+const (
+	StatsLast7Days   = "last_7_days"
+	StatsLast30Days  = "last_30_days"
+	StatsLast6Months = "last_6_months"
+	StatsLastYear    = "last_year"
+	StatsAllTime     = "all_time"
+)
+
+type StatsResponse struct {
+	Data Stats `json:"data"`
+}
+
+type Stats struct {
+	TotalSeconds                                    float64       `json:"total_seconds"`
+	TotalSecondsIncludingOtherLanguage              float64       `json:"total_seconds_including_other_language"`
+	HumanReadableTotal                              string        `json:"human_readable_total"`
+	HumanReadableTotalIncludingOtherLanguage        string        `json:"human_readable_total_including_other_language"`
+	DailyAverage                                    float64       `json:"daily_average"`
+	DailyAverageIncludingOtherLanguage              float64       `json:"daily_average_including_other_language"`
+	HumanReadableDailyAverage                       string        `json:"human_readable_daily_average"`
+	HumanReadableDailyAverageIncludingOtherLanguage string        `json:"human_readable_daily_average_including_other_language"`
+	Categories                                      []SummaryItem `json:"categories"`
+	Projects                                        []SummaryItem `json:"projects"`
+	Languages                                       []SummaryItem `json:"languages"`
+	Editors                                         []SummaryItem `json:"editors"`
+	OperatingSystems                                []SummaryItem `json:"operating_systems"`
+	Dependencies                                    []SummaryItem `json:"dependencies"`
+	Machines                                        []SummaryItem `json:"machines"`
+	BestDay                                         *BestDay      `json:"best_day"`
+	Range                                           string        `json:"range"`
+	HumanReadableRange                              string        `json:"human_readable_range"`
+	Holidays                                        int           `json:"holidays"`
+	DaysIncludingHolidays                           int           `json:"days_including_holidays"`
+	DaysMinusHolidays                               int           `json:"days_minus_holidays"`
+	Status                                          string        `json:"status"`
+	PercentCalculated                               float64       `json:"percent_calculated"`
+	IsAlreadyUpdating                               bool          `json:"is_already_updating"`
+	IsCodingActivityVisible                         bool          `json:"is_coding_activity_visible"`
+	IsOtherUsageVisible                             bool          `json:"is_other_usage_visible"`
+	IsStuck                                         bool          `json:"is_stuck"`
+	IsIncludingToday                                bool          `json:"is_including_today"`
+	IsUpToDate                                      bool          `json:"is_up_to_date"`
+	Start                                           time.Time     `json:"start"`
+	End                                             time.Time     `json:"end"`
+	Timezone                                        string        `json:"timezone"`
+	Timeout                                         int           `json:"timeout"`
+	WritesOnly                                      bool          `json:"writes_only"`
+	UserID                                          string        `json:"user_id"`
+	Username                                        string        `json:"username"`
+	CreatedAt                                       *time.Time    `json:"created_at"`
+	ModifiedAt                                      *time.Time    `json:"modified_at"`
+}
+
+type BestDay struct {
+	Date         string  `json:"date"`
+	Text         string  `json:"text"`
+	TotalSeconds float64 `json:"total_seconds"`
+}
+
+type AllTimeSinceTodayResponse struct {
+	Data AllTimeSinceToday `json:"data"`
+}
+
+type AllTimeSinceToday struct {
+	TotalSeconds      float64                `json:"total_seconds"`
+	Text              string                 `json:"text"`
+	Decimal           string                 `json:"decimal"`
+	Digital           string                 `json:"digital"`
+	DailyAverage      float64                `json:"daily_average"`
+	IsUpToDate        bool                   `json:"is_up_to_date"`
+	PercentCalculated float64                `json:"percent_calculated"`
+	Range             AllTimeSinceTodayRange `json:"range"`
+	Timeout           int                    `json:"timeout"`
+}
+
+type AllTimeSinceTodayRange struct {
+	Start     time.Time `json:"start"`
+	StartDate string    `json:"start_date"`
+	StartText string    `json:"start_text"`
+	End       time.Time `json:"end"`
+	EndDate   string    `json:"end_date"`
+	EndText   string    `json:"end_text"`
+	Timezone  string    `json:"timezone"`
+}
+
+type StatusBarResponse struct {
+	Data SummariesData `json:"data"`
+}
@@ -1,7 +1,9 @@
 package wakatime
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
 
 	"github.com/zalando/go-keyring"
 )
@@ -9,9 +11,32 @@ import (
 var serviceName string = "wakalog"
 var userName string = "wakatime_api_key"
 
-func StoreAPIKey(apiKey string) error {
+// APIKeyEnv is the environment variable checked for a WakaTime API Key before storage (Keyring)
+const APIKeyEnv = "WAKATIME_API_KEY"
 
-	err := keyring.Set(serviceName, userName, apiKey)
+// keyringUser returns the keyring entry of the API Key for baseURL. Keys are stored per host so multiple backends can coexist,
+// with the WakaTime API using the entry of earlier versions.
+func keyringUser(baseURL string) string {
+
+	u, err := url.Parse(baseURL)
+
+	if err != nil || baseURL == "" || baseURL == DefaultBaseURL {
+		return userName
+	}
+
+	defaultURL, _ := url.Parse(DefaultBaseURL)
+
+	if u.Host == defaultURL.Host {
+		return userName
+	}
+
+	return fmt.Sprintf("%s@%s", userName, u.Host)
+
+}
+
+func StoreAPIKey(baseURL string, apiKey string) error {
+
+	err := keyring.Set(serviceName, keyringUser(baseURL), apiKey)
 
 	if err != nil {
 		return err
@@ -21,9 +46,9 @@ func StoreAPIKey(apiKey string) error {
 
 }
 
-func GetAPIKey(apiKeyDest *string) error {
+func GetAPIKey(baseURL string, apiKeyDest *string) error {
 
-	apiKey, err := keyring.Get(serviceName, userName)
+	apiKey, err := keyring.Get(serviceName, keyringUser(baseURL))
 
 	if err != nil {
 		return fmt.Errorf("error retreiving wakatime api key: %w", err)
@@ -34,3 +59,16 @@ func GetAPIKey(apiKeyDest *string) error {
 	return nil
 
 }
+
+// DeleteAPIKey removes the API Key for baseURL from storage (Keyring). Deleting a missing API Key is not an error.
+func DeleteAPIKey(baseURL string) error {
+
+	err := keyring.Delete(serviceName, keyringUser(baseURL))
+
+	if err != nil && !errors.Is(err, keyring.ErrNotFound) {
+		return fmt.Errorf("error deleting wakatime api key: %w", err)
+	}
+
+	return nil
+
+}
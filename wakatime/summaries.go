@@ -11,8 +11,41 @@ type Summaries struct {
 }
 
 type SummariesData struct {
-	GrandTotal GrandTotal `json:"grand_total"`
-	Projects   []Project  `json:"projects"`
+	GrandTotal       GrandTotal    `json:"grand_total"`
+	Projects         []Project     `json:"projects"`
+	Languages        []SummaryItem `json:"languages"`
+	Editors          []SummaryItem `json:"editors"`
+	Categories       []SummaryItem `json:"categories"`
+	OperatingSystems []SummaryItem `json:"operating_systems"`
+	Machines         []SummaryItem `json:"machines"`
+	Branches         []SummaryItem `json:"branches"`
+	Entities         []SummaryItem `json:"entities"`
+	Dependencies     []SummaryItem `json:"dependencies"`
+	Range            SummaryRange  `json:"range"`
+}
+
+// SummaryItem is the time spent on a language, editor, category, operating system, machine, branch, entity or dependency
+type SummaryItem struct {
+	Name          string  `json:"name"`
+	TotalSeconds  float64 `json:"total_seconds"`
+	Digital       string  `json:"digital"`
+	Decimal       string  `json:"decimal"`
+	Text          string  `json:"text"`
+	Hours         int64   `json:"hours"`
+	Minutes       int64   `json:"minutes"`
+	Seconds       int64   `json:"seconds"`
+	Percent       float64 `json:"percent"`
+	MachineNameID *string `json:"machine_name_id,omitempty"`
+	// Type is the type of an entity e.g. file, app or domain
+	Type string `json:"type,omitempty"`
+}
+
+type SummaryRange struct {
+	Date     string    `json:"date"`
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	Text     string    `json:"text"`
+	Timezone string    `json:"timezone"`
 }
 
 type GrandTotal struct {
@@ -45,11 +78,11 @@ type CumulativeTotal struct {
 	Decimal string  `json:"decimal"`
 }
 type DailyAverage struct {
-	Holidays                      int    `json:"holidays"`
-	DaysMinusHolidays             int    `json:"days_minus_holidays"`
-	DaysIncludingHolidays         int    `json:"days_including_holidays"`
-	Seconds                       int    `json:"seconds"`
-	SecondsIncludingOtherLanguage int    `json:"seconds_including_other_language"`
-	Text                          string `json:"text"`
-	TextIncludingOtherLanguage    string `json:"text_including_other_language"`
+	Holidays                      int     `json:"holidays"`
+	DaysMinusHolidays             int     `json:"days_minus_holidays"`
+	DaysIncludingHolidays         int     `json:"days_including_holidays"`
+	Seconds                       float64 `json:"seconds"`
+	SecondsIncludingOtherLanguage float64 `json:"seconds_including_other_language"`
+	Text                          string  `json:"text"`
+	TextIncludingOtherLanguage    string  `json:"text_including_other_language"`
 }
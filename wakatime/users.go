@@ -0,0 +1,46 @@
+package wakatime
+
+import (
+	"fmt"
+	"time"
+)
+
+type CurrentUser struct {
+	Data User `json:"data"`
+}
+
+type User struct {
+	ID               string     `json:"id"`
+	Username         string     `json:"username"`
+	DisplayName      string     `json:"display_name"`
+	FullName         string     `json:"full_name"`
+	Email            string     `json:"email"`
+	Photo            string     `json:"photo"`
+	Timezone         string     `json:"timezone"`
+	Plan             string     `json:"plan"`
+	LastHeartbeatAt  *time.Time `json:"last_heartbeat_at"`
+	LastPlugin       string     `json:"last_plugin"`
+	LastPluginName   string     `json:"last_plugin_name"`
+	LastProject      string     `json:"last_project"`
+	IsEmailPublic    bool       `json:"is_email_public"`
+	IsEmailConfirmed bool       `json:"is_email_confirmed"`
+	CreatedAt        time.Time  `json:"created_at"`
+	ModifiedAt       *time.Time `json:"modified_at"`
+}
+
+// String returns the user's username (or display name) along with their email if available
+func (u *User) String() string {
+
+	name := u.Username
+
+	if name == "" {
+		name = u.DisplayName
+	}
+
+	if u.Email != "" {
+		return fmt.Sprintf("%s <%s>", name, u.Email)
+	}
+
+	return name
+
+}
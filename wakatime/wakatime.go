@@ -1,21 +1,131 @@
 package wakatime
 
-import "github.com/Youngtard/wakalog/httpclient"
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+
+	"github.com/Youngtard/wakalog/httpclient"
+)
 
 const (
 	apiVersion = "/api/v1"
-	baseURL    = "https://api.wakatime.com" + apiVersion
+
+	// DefaultBaseURL is the base URL of the WakaTime API
+	DefaultBaseURL = "https://api.wakatime.com" + apiVersion
 )
 
+// BaseURLEnv is the environment variable overriding the configured base URL
+const BaseURLEnv = "WAKATIME_API_URL"
+
 type Client struct {
 	httpclient *httpclient.Client
+	baseURL    string
+	logger     *slog.Logger
+	trace      io.Writer
+	// retryPolicy overrides httpclient.DefaultRetryPolicy for clients created with NewClientWithAPIKey
+	retryPolicy *httpclient.RetryPolicy
+	cache       httpclient.Cache
+}
+
+// ClientOption configures a Client
+type ClientOption func(c *Client)
+
+// WithBaseURL sets the base URL of a WakaTime compatible API e.g. https://wakapi.dev/api/compat/wakatime/v1
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		if baseURL != "" {
+			c.baseURL = baseURL
+		}
+	}
 }
 
-func NewClient(hClient *httpclient.Client) *Client {
+// WithLogger logs requests to logger, and traces them to trace when not nil. Applies to clients created with NewClientWithAPIKey.
+func WithLogger(logger *slog.Logger, trace io.Writer) ClientOption {
+	return func(c *Client) {
+		c.logger = logger
+		c.trace = trace
+	}
+}
+
+// WithRetryPolicy sets the retry policy of clients created with NewClientWithAPIKey, httpclient.DefaultRetryPolicy otherwise.
+// A zero policy disables retries.
+func WithRetryPolicy(policy httpclient.RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = &policy
+	}
+}
+
+// WithCache revalidates responses stored in cache instead of downloading them again. Applies to clients created with NewClientWithAPIKey.
+func WithCache(cache httpclient.Cache) ClientOption {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+func NewClient(hClient *httpclient.Client, opts ...ClientOption) *Client {
 
 	c := &Client{
 		httpclient: hClient,
+		baseURL:    DefaultBaseURL,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+
+}
+
+// NewClientWithAPIKey returns a client authenticating requests with apiKey
+func NewClientWithAPIKey(apiKey string, opts ...ClientOption) *Client {
+
+	encodedKey := base64.StdEncoding.EncodeToString([]byte(apiKey))
+
+	c := NewClient(httpclient.NewClient(nil), opts...)
+
+	if c.logger != nil {
+		c.httpclient = c.httpclient.WithLogger(c.logger, c.trace)
+	}
+
+	/// Set before auth so the API key is part of cache keys
+	if c.cache != nil {
+		c.httpclient = c.httpclient.WithCache(c.cache)
+	}
+
+	retryPolicy := httpclient.DefaultRetryPolicy()
+
+	if c.retryPolicy != nil {
+		retryPolicy = *c.retryPolicy
 	}
+
+	c.httpclient = c.httpclient.WithBasicAuth(encodedKey).WithRetryPolicy(retryPolicy)
+
 	return c
 
 }
+
+// BaseURL returns the base URL requests are sent to
+func (r *Client) BaseURL() string {
+	return r.baseURL
+}
+
+// ValidateBaseURL checks baseURL is an absolute http(s) URL
+func ValidateBaseURL(baseURL string) error {
+
+	u, err := url.Parse(baseURL)
+
+	if err != nil {
+		return fmt.Errorf("invalid api url %q: %w", baseURL, err)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid api url %q: expected an http(s) URL e.g. %s", baseURL, DefaultBaseURL)
+	}
+
+	return nil
+
+}